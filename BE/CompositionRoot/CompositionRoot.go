@@ -2,6 +2,7 @@ package CompositionRoot
 
 import (
 	"ChoHanJi/config/PilgrimCraftConfig"
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/drivers/http/delegatingHandlers/GenericPanicCatcher"
 	"ChoHanJi/drivers/http/delegatingHandlers/JobNameAttacher"
 	"ChoHanJi/drivers/http/delegatingHandlers/LoggerAttacher"
@@ -32,7 +33,7 @@ func CreateEndPoints(container gi.Container, config *PilgrimCraftConfig.PilgrimC
 		w.WriteHeader(http.StatusNotFound)
 	}))
 
-	origin := fmt.Sprintf("%s:%s", config.Server.Host, "3000")
+	origin := config.Server.Origin()
 
 	r.Mount("/api/room", RegisterPOSTRoom(container, handlers.POSTRoom, origin))
 	r.Mount("/api/character", RegisterPOSTPlayer(container, handlers.POSTCharacter, origin))
@@ -41,15 +42,32 @@ func CreateEndPoints(container gi.Container, config *PilgrimCraftConfig.PilgrimC
 	r.Mount(string(handlers.POSTGameStart), RegisterPOSTGameStart(container, handlers.POSTGameStart, origin))
 
 	r.Mount(string(handlers.GETAdminGameStatus), RegisterGETEndPoint(container, string(handlers.GETAdminGameStatus), origin))
+	r.Mount(string(handlers.GETSpectatorGameStatus), RegisterGETEndPoint(container, string(handlers.GETSpectatorGameStatus), origin))
 	r.Mount(string(handlers.GETPlayerGameStatus), RegisterGETEndPoint(container, string(handlers.GETPlayerGameStatus), origin))
 
 	r.Mount(string(handlers.POSTSubmitMoves), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitMoves), origin))
 	r.Mount(string(handlers.POSTSubmitAttacks), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitAttacks), origin))
 	r.Mount(string(handlers.POSTSubmitAttackResult), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitAttackResult), origin))
 	r.Mount(string(handlers.POSTSubmitBonusAttacks), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitBonusAttacks), origin))
+	r.Mount(string(handlers.POSTSubmitAbility), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitAbility), origin))
+	r.Mount(string(handlers.POSTSubmitDrop), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitDrop), origin))
+	r.Mount(string(handlers.POSTSubmitUse), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitUse), origin))
+	r.Mount(string(handlers.POSTSubmitRaid), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitRaid), origin))
 	r.Mount(string(handlers.POSTSubmitSkip), RegisterPOSTEndPoint(container, string(handlers.POSTSubmitSkip), origin))
+	r.Mount(string(handlers.POSTWithdraw), RegisterPOSTEndPoint(container, string(handlers.POSTWithdraw), origin))
+	r.Mount(string(handlers.GETClasses), RegisterGETEndPoint(container, string(handlers.GETClasses), origin))
+	r.Mount(string(handlers.GETPendingActions), RegisterGETEndPoint(container, string(handlers.GETPendingActions), origin))
+	r.Mount(string(handlers.GETReachable), RegisterGETEndPoint(container, string(handlers.GETReachable), origin))
 
 	r.Mount(string(handlers.POSTProceed), RegisterPOSTEndPoint(container, string(handlers.POSTProceed), origin))
+	r.Mount(string(handlers.POSTRequireAllReady), RegisterPOSTEndPoint(container, string(handlers.POSTRequireAllReady), origin))
+
+	r.Mount(string(handlers.GETWebSocket), RegisterGETEndPoint(container, string(handlers.GETWebSocket), origin))
+
+	r.Mount(string(handlers.POSTLobbyMove), RegisterPOSTEndPoint(container, string(handlers.POSTLobbyMove), origin))
+	r.Mount(string(handlers.POSTLobbyKick), RegisterPOSTEndPoint(container, string(handlers.POSTLobbyKick), origin))
+	r.Mount(string(handlers.POSTLobbyLeave), RegisterPOSTEndPoint(container, string(handlers.POSTLobbyLeave), origin))
+	r.Mount(string(handlers.POSTLobbyRename), RegisterPOSTEndPoint(container, string(handlers.POSTLobbyRename), origin))
 
 	return r, nil
 }
@@ -79,8 +97,7 @@ func RegisterPOSTRoom(container gi.Container, route handlers.RouteToken, origin
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, "POST /api/room: Could not resolve handler", slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, "POST /api/room: Could not resolve handler", err)
 			return
 		}
 
@@ -115,8 +132,7 @@ func RegisterPOSTPlayer(container gi.Container, route handlers.RouteToken, origi
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, "POST /api/character: Could not resolve handler", slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, "POST /api/character: Could not resolve handler", err)
 			return
 		}
 
@@ -151,8 +167,7 @@ func RegisterPOSTGameStart(container gi.Container, route handlers.RouteToken, or
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, "POST /api/game/start: Could not resolve handler", slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, "POST /api/game/start: Could not resolve handler", err)
 			return
 		}
 
@@ -183,8 +198,7 @@ func RegisterAdminWaitingRoom(container gi.Container, route handlers.RouteToken,
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, "GET /api/room/admin: Could not resolve handler", slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, "GET /api/room/admin: Could not resolve handler", err)
 			return
 		}
 
@@ -215,8 +229,7 @@ func RegisterGETPlayerEvent(container gi.Container, route string, origin string)
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, "POST /api/player/event: Could not resolve handler", slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, "POST /api/player/event: Could not resolve handler", err)
 			return
 		}
 
@@ -247,8 +260,7 @@ func RegisterGETGameStatus(container gi.Container, route, origin string) *chi.Mu
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, "GET /api/game: Could not resolve handler", slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, "GET /api/game: Could not resolve handler", err)
 			return
 		}
 
@@ -279,8 +291,7 @@ func RegisterGETEndPoint(container gi.Container, route, origin string) *chi.Mux
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, fmt.Sprintf("%s %s: Could not resolve handler", GET, route), slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, fmt.Sprintf("%s %s: Could not resolve handler", GET, route), err)
 			return
 		}
 
@@ -311,8 +322,7 @@ func RegisterPOSTEndPoint(container gi.Container, route, origin string) *chi.Mux
 
 		handler, err := GoFac.ResolveNamed[http.Handler](container, context, string(route))
 		if err != nil {
-			logger.ErrorContext(context, fmt.Sprintf("%s %s: Could not resolve handler", POST, route), slog.Any("Error", err))
-			w.WriteHeader(http.StatusInternalServerError)
+			Problem.Send(context, w, logger, http.StatusInternalServerError, fmt.Sprintf("%s %s: Could not resolve handler", POST, route), err)
 			return
 		}
 
@@ -1,36 +1,60 @@
 package bootstrap
 
 import (
+	"ChoHanJi/config/PilgrimCraftConfig"
 	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Class"
+	"ChoHanJi/domain/Cooldown"
 	"ChoHanJi/domain/Death"
 	"ChoHanJi/domain/Fight"
 	"ChoHanJi/domain/PlayerBlocker"
 	"ChoHanJi/domain/Room"
+	"ChoHanJi/driven/redis/RedisHub"
 	"ChoHanJi/driven/sse/SSEHub"
 	"ChoHanJi/drivers/http/handlers"
+	"ChoHanJi/drivers/http/handlers/Classes"
 	"ChoHanJi/drivers/http/handlers/CreateCharacter"
 	"ChoHanJi/drivers/http/handlers/CreateRoom"
 	AdminGameStatus "ChoHanJi/drivers/http/handlers/GameStatus/Admin"
 	PlayerGameStatus "ChoHanJi/drivers/http/handlers/GameStatus/Player"
+	SpectatorGameStatus "ChoHanJi/drivers/http/handlers/GameStatus/Spectator"
+	"ChoHanJi/drivers/http/handlers/KickPlayer"
+	"ChoHanJi/drivers/http/handlers/LeaveRoom"
+	"ChoHanJi/drivers/http/handlers/MovePlayer"
+	"ChoHanJi/drivers/http/handlers/PendingActions"
 	"ChoHanJi/drivers/http/handlers/PlayerRoom"
 	"ChoHanJi/drivers/http/handlers/Proceed"
+	"ChoHanJi/drivers/http/handlers/Reachable"
+	"ChoHanJi/drivers/http/handlers/RenamePlayer"
+	"ChoHanJi/drivers/http/handlers/RequireAllReady"
 	"ChoHanJi/drivers/http/handlers/SkipMove"
 	"ChoHanJi/drivers/http/handlers/StartGame"
+	"ChoHanJi/drivers/http/handlers/SubmitAbility"
 	"ChoHanJi/drivers/http/handlers/SubmitAttacks"
 	"ChoHanJi/drivers/http/handlers/SubmitBonusAttack"
+	"ChoHanJi/drivers/http/handlers/SubmitDrop"
 	"ChoHanJi/drivers/http/handlers/SubmitFightResult"
 	"ChoHanJi/drivers/http/handlers/SubmitMoves"
+	"ChoHanJi/drivers/http/handlers/SubmitRaid"
+	"ChoHanJi/drivers/http/handlers/SubmitUse"
 	"ChoHanJi/drivers/http/handlers/WaitingRoom"
+	"ChoHanJi/drivers/http/handlers/WebSocket"
+	"ChoHanJi/drivers/http/handlers/Withdraw"
 	"ChoHanJi/useCases/AdminWaitingRoomUseCase"
 	"ChoHanJi/useCases/CharacterFactory"
+	"ChoHanJi/useCases/ClassesUseCase"
 	"ChoHanJi/useCases/GameStatus"
+	"ChoHanJi/useCases/LobbyUseCase"
+	"ChoHanJi/useCases/PendingActionsUseCase"
 	"ChoHanJi/useCases/PlayerWaitingRoomUseCase"
 	"ChoHanJi/useCases/ProceedUseCase"
+	"ChoHanJi/useCases/ReachableUseCase"
 	"ChoHanJi/useCases/RoomFactory"
 	"ChoHanJi/useCases/RoomFactory/ports"
 	"ChoHanJi/useCases/StartGameUseCase"
 	"ChoHanJi/useCases/SubmitFightResultUseCase"
 	"ChoHanJi/useCases/SubmitMoveUseCase"
+	"ChoHanJi/useCases/WebSocketUseCase"
 	"context"
 	"fmt"
 	"net/http"
@@ -38,11 +62,12 @@ import (
 	gi "github.com/TaBSRest/GoFac/interfaces"
 	cb "github.com/TaBSRest/GoFac/pkg/ContainerBuilder"
 	"github.com/go-playground/validator/v10"
+	"github.com/redis/go-redis/v9"
 
 	o "github.com/TaBSRest/GoFac/pkg/Options/Registration"
 )
 
-func Register(ctx context.Context) gi.Container {
+func Register(ctx context.Context, config *PilgrimCraftConfig.PilgrimCraftConfig) gi.Container {
 	cb := cb.New()
 
 	if err := RegisterDrivers(ctx, cb); err != nil {
@@ -57,11 +82,11 @@ func Register(ctx context.Context) gi.Container {
 		panic(fmt.Errorf("could not register domains! %w", err))
 	}
 
-	if err := RegisterDriven(ctx, cb); err != nil {
+	if err := RegisterDriven(ctx, cb, config); err != nil {
 		panic(fmt.Errorf("could not register driven adapters! %w", err))
 	}
 
-	if err := RegisterExternalDependencies(ctx, cb); err != nil {
+	if err := RegisterExternalDependencies(ctx, cb, config); err != nil {
 		panic(fmt.Errorf("could not register external dependencies! %w", err))
 	}
 
@@ -137,6 +162,15 @@ func RegisterDrivers(ctx context.Context, builder *cb.ContainerBuilder) error {
 		return err
 	}
 
+	if err := builder.Register(
+		SpectatorGameStatus.New,
+		o.AsSingleton,
+		o.Named(string(handlers.GETSpectatorGameStatus)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
 	if err := builder.Register(
 		SubmitMoves.New,
 		o.AsSingleton,
@@ -164,6 +198,42 @@ func RegisterDrivers(ctx context.Context, builder *cb.ContainerBuilder) error {
 		return err
 	}
 
+	if err := builder.Register(
+		SubmitAbility.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTSubmitAbility)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		SubmitDrop.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTSubmitDrop)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		SubmitRaid.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTSubmitRaid)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		SubmitUse.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTSubmitUse)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
 	if err := builder.Register(
 		SubmitBonusAttack.New,
 		o.AsSingleton,
@@ -182,6 +252,42 @@ func RegisterDrivers(ctx context.Context, builder *cb.ContainerBuilder) error {
 		return err
 	}
 
+	if err := builder.Register(
+		Withdraw.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTWithdraw)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		Classes.New,
+		o.AsSingleton,
+		o.Named(string(handlers.GETClasses)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		PendingActions.New,
+		o.AsSingleton,
+		o.Named(string(handlers.GETPendingActions)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		Reachable.New,
+		o.AsSingleton,
+		o.Named(string(handlers.GETReachable)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
 	if err := builder.Register(
 		Proceed.New,
 		o.AsSingleton,
@@ -191,6 +297,60 @@ func RegisterDrivers(ctx context.Context, builder *cb.ContainerBuilder) error {
 		return err
 	}
 
+	if err := builder.Register(
+		RequireAllReady.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTRequireAllReady)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		WebSocket.New,
+		o.AsSingleton,
+		o.Named(string(handlers.GETWebSocket)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		MovePlayer.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTLobbyMove)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		KickPlayer.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTLobbyKick)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		LeaveRoom.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTLobbyLeave)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		RenamePlayer.New,
+		o.AsSingleton,
+		o.Named(string(handlers.POSTLobbyRename)),
+		o.As[http.Handler],
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -239,6 +399,7 @@ func RegisterUseCases(ctx context.Context, builder *cb.ContainerBuilder) error {
 		GameStatus.New,
 		o.AsSingleton,
 		o.As[GameStatus.Interface],
+		o.As[WebSocketUseCase.IGameSnapshot],
 	); err != nil {
 		return err
 	}
@@ -263,6 +424,48 @@ func RegisterUseCases(ctx context.Context, builder *cb.ContainerBuilder) error {
 		ProceedUseCase.New,
 		o.AsSingleton,
 		o.As[ProceedUseCase.Interface],
+		o.As[StartGameUseCase.IPlanningTimer],
+		o.As[SubmitMoveUseCase.IPlanningTimer],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		ClassesUseCase.New,
+		o.AsSingleton,
+		o.As[ClassesUseCase.Interface],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		PendingActionsUseCase.New,
+		o.AsSingleton,
+		o.As[PendingActionsUseCase.Interface],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		ReachableUseCase.New,
+		o.AsSingleton,
+		o.As[ReachableUseCase.Interface],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		WebSocketUseCase.New,
+		o.AsSingleton,
+		o.As[WebSocketUseCase.Interface],
+	); err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		LobbyUseCase.New,
+		o.AsSingleton,
+		o.As[LobbyUseCase.Interface],
 	); err != nil {
 		return err
 	}
@@ -284,6 +487,8 @@ func RegisterDomains(ctx context.Context, builder *cb.ContainerBuilder) error {
 		o.As[StartGameUseCase.IActionList],
 		o.As[SubmitMoveUseCase.IActionList],
 		o.As[ProceedUseCase.ActionList],
+		o.As[PendingActionsUseCase.IActionList],
+		o.As[GameStatus.IActionList],
 	); err != nil {
 		return err
 	}
@@ -306,10 +511,20 @@ func RegisterDomains(ctx context.Context, builder *cb.ContainerBuilder) error {
 		return err
 	}
 
+	if err := builder.Register(
+		Cooldown.New,
+		o.AsSingleton,
+		o.As[Action.ICooldowns],
+		o.As[SubmitMoveUseCase.ICooldowns],
+	); err != nil {
+		return err
+	}
+
 	if err := builder.Register(
 		Death.NewDeathList,
 		o.AsSingleton,
 		o.As[Action.DeathList],
+		o.As[SubmitFightResultUseCase.IDeathList],
 	); err != nil {
 		return err
 	}
@@ -326,24 +541,33 @@ func RegisterDomains(ctx context.Context, builder *cb.ContainerBuilder) error {
 	return nil
 }
 
-func RegisterDriven(ctx context.Context, builder *cb.ContainerBuilder) error {
+func RegisterDriven(ctx context.Context, builder *cb.ContainerBuilder, config *PilgrimCraftConfig.PilgrimCraftConfig) error {
+	lobbyHub, gameHub, err := hubConstructors(ctx, config.Hub)
+	if err != nil {
+		return err
+	}
+
 	if err := builder.Register(
-		SSEHub.New,
+		lobbyHub,
 		o.AsSingleton,
 		o.As[AdminWaitingRoomUseCase.IHub],
 		o.As[PlayerWaitingRoomUseCase.IHub],
 		o.As[StartGameUseCase.IHub],
+		o.As[WebSocketUseCase.ILobbyHub],
+		o.As[LobbyUseCase.IHub],
 	); err != nil {
 		return err
 	}
 
 	if err := builder.Register(
-		SSEHub.New,
+		gameHub,
 		o.AsSingleton,
 		o.As[GameStatus.IHub],
 		o.As[SubmitMoveUseCase.IHub],
 		o.As[Action.IHub],
 		o.As[SubmitFightResultUseCase.IHub],
+		o.As[ProceedUseCase.IHub],
+		o.As[WebSocketUseCase.IGameHub],
 	); err != nil {
 		return err
 	}
@@ -351,7 +575,58 @@ func RegisterDriven(ctx context.Context, builder *cb.ContainerBuilder) error {
 	return nil
 }
 
-func RegisterExternalDependencies(ctx context.Context, builder *cb.ContainerBuilder) error {
+// hubConstructors returns the constructors of the lobby hub and the game hub for the configured backend.
+func hubConstructors(ctx context.Context, config PilgrimCraftConfig.HubConfig) (any, any, error) {
+	switch config.Backend {
+	case "", PilgrimCraftConfig.HubBackendMemory:
+		return SSEHub.New, SSEHub.New, nil
+	case PilgrimCraftConfig.HubBackendRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     config.RedisAddr,
+			Password: config.RedisPassword,
+			DB:       config.RedisDB,
+		})
+
+		lobbyHub := func() (*RedisHub.Struct, error) {
+			return RedisHub.New(ctx, client, "lobby")
+		}
+		gameHub := func() (*RedisHub.Struct, error) {
+			return RedisHub.New(ctx, client, "game")
+		}
+		return lobbyHub, gameHub, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown hub backend %q", config.Backend)
+	}
+}
+
+func RegisterExternalDependencies(ctx context.Context, builder *cb.ContainerBuilder, config *PilgrimCraftConfig.PilgrimCraftConfig) error {
+	if err := builder.Register(
+		func() *PilgrimCraftConfig.PilgrimCraftConfig {
+			return config
+		},
+		o.AsSingleton,
+	); err != nil {
+		return err
+	}
+
+	// The classes are loaded here so that a broken definition stops the boot.
+	classes, err := loadClasses(config.Classes)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.Register(
+		func() *Class.Registry {
+			return classes
+		},
+		o.AsSingleton,
+		o.As[CharacterFactory.IClasses],
+		o.As[RoomFactory.IClasses],
+		o.As[ClassesUseCase.IClasses],
+	); err != nil {
+		return err
+	}
+
 	if err := builder.Register(
 		func() *validator.Validate {
 			return validator.New()
@@ -362,3 +637,11 @@ func RegisterExternalDependencies(ctx context.Context, builder *cb.ContainerBuil
 	}
 	return nil
 }
+
+// loadClasses returns the classes of the configured file, or the shipped ones.
+func loadClasses(config PilgrimCraftConfig.ClassesConfig) (*Class.Registry, error) {
+	if config.File == "" {
+		return Class.Default()
+	}
+	return Class.LoadFile(config.File)
+}
@@ -0,0 +1,342 @@
+// eventschema generates the JSON Schema and the TypeScript definitions of the
+// messages sent to the clients, so that the frontend stays in sync with
+// the Event package. Run it through `go generate ./domain/Event`.
+package main
+
+import (
+	"ChoHanJi/domain/Event"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+)
+
+type field struct {
+	name     string
+	t        reflect.Type
+	optional bool
+}
+
+type generator struct {
+	defs  map[string][]field
+	order []string
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "path of the generated JSON Schema")
+	tsPath := flag.String("ts", "", "path of the generated TypeScript definitions")
+	flag.Parse()
+
+	g := &generator{defs: make(map[string][]field)}
+	for _, event := range Event.All {
+		g.define(reflect.TypeOf(event))
+	}
+
+	if *schemaPath != "" {
+		if err := write(*schemaPath, g.schema()); err != nil {
+			panic(err)
+		}
+	}
+
+	if *tsPath != "" {
+		if err := write(*tsPath, g.typeScript()); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func write(filePath string, content []byte) error {
+	if err := os.MkdirAll(path.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("eventschema: Could not create the directory of %s: %w", filePath, err)
+	}
+	if err := os.WriteFile(filePath, content, 0o644); err != nil {
+		return fmt.Errorf("eventschema: Could not write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func typeName(t reflect.Type) string {
+	pkg := path.Base(t.PkgPath())
+	name := t.Name()
+
+	switch {
+	case pkg == "Event":
+		return strings.TrimSuffix(name, "Struct") + "Event"
+	case name == "Struct":
+		return pkg
+	default:
+		return pkg + name
+	}
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// define registers the struct definitions reachable from t.
+func (g *generator) define(t reflect.Type) {
+	t = deref(t)
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		g.define(t.Elem())
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	name := typeName(t)
+	if _, found := g.defs[name]; found {
+		return
+	}
+
+	g.defs[name] = nil
+	fields := jsonFields(t)
+	g.defs[name] = fields
+	g.order = append(g.order, name)
+
+	for _, f := range fields {
+		g.define(f.t)
+	}
+}
+
+type candidate struct {
+	field
+	depth  int
+	tagged bool
+}
+
+// jsonFields mirrors how encoding/json picks the fields of a struct: embedded
+// structs are flattened and, among fields sharing a name, the shallowest
+// tagged one wins.
+func jsonFields(t reflect.Type) []field {
+	var candidates []candidate
+	collect(t, 0, &candidates)
+
+	var names []string
+	byName := make(map[string][]candidate)
+	for _, c := range candidates {
+		if _, found := byName[c.name]; !found {
+			names = append(names, c.name)
+		}
+		byName[c.name] = append(byName[c.name], c)
+	}
+
+	var fields []field
+	for _, name := range names {
+		if f, ok := dominant(byName[name]); ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func collect(t reflect.Type, depth int, out *[]candidate) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" && deref(f.Type).Kind() == reflect.Struct {
+			collect(deref(f.Type), depth+1, out)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = f.Name
+		}
+
+		*out = append(*out, candidate{
+			field:  field{name, f.Type, strings.Contains(options, "omitempty")},
+			depth:  depth,
+			tagged: tagged,
+		})
+	}
+}
+
+func dominant(candidates []candidate) (field, bool) {
+	depth := candidates[0].depth
+	for _, c := range candidates {
+		depth = min(depth, c.depth)
+	}
+
+	var shallowest, tagged []candidate
+	for _, c := range candidates {
+		if c.depth != depth {
+			continue
+		}
+		shallowest = append(shallowest, c)
+		if c.tagged {
+			tagged = append(tagged, c)
+		}
+	}
+
+	switch {
+	case len(tagged) == 1:
+		return tagged[0].field, true
+	case len(tagged) == 0 && len(shallowest) == 1:
+		return shallowest[0].field, true
+	default:
+		return field{}, false
+	}
+}
+
+func (g *generator) schema() []byte {
+	defs := make(map[string]any)
+	for _, name := range g.order {
+		properties := make(map[string]any)
+		var required []string
+		for _, f := range g.defs[name] {
+			properties[f.name] = schemaType(f.t)
+			if !f.optional {
+				required = append(required, f.name)
+			}
+		}
+
+		def := map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if len(required) > 0 {
+			def["required"] = required
+		}
+		defs[name] = def
+	}
+
+	var envelopes []any
+	for _, event := range Event.All {
+		envelopes = append(envelopes, map[string]any{
+			"type":     "object",
+			"required": []string{"Version", "MessageType", "Message"},
+			"properties": map[string]any{
+				"Version":     map[string]any{"const": Event.SchemaVersion},
+				"MessageType": map[string]any{"const": event.Type()},
+				"Message":     map[string]any{"$ref": "#/$defs/" + typeName(reflect.TypeOf(event))},
+			},
+		})
+	}
+
+	document := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     "events.schema.json",
+		"title":   "ServerMessage",
+		"oneOf":   envelopes,
+		"$defs":   defs,
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return append(content, '\n')
+}
+
+func schemaType(t reflect.Type) map[string]any {
+	nullable := func(s map[string]any) map[string]any {
+		return map[string]any{"oneOf": []any{s, map[string]any{"type": "null"}}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(schemaType(t.Elem()))
+	case reflect.Struct:
+		return map[string]any{"$ref": "#/$defs/" + typeName(t)}
+	case reflect.Slice, reflect.Array:
+		return nullable(map[string]any{"type": "array", "items": schemaType(deref(t.Elem()))})
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": schemaType(deref(t.Elem()))})
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (g *generator) typeScript() []byte {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by go generate ./domain/Event; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "export const SchemaVersion = %d;\n", Event.SchemaVersion)
+
+	for _, name := range g.order {
+		fields := g.defs[name]
+		if len(fields) == 0 {
+			fmt.Fprintf(&b, "\nexport type %s = Record<string, never>;\n", name)
+			continue
+		}
+
+		fmt.Fprintf(&b, "\nexport type %s = {\n", name)
+		for _, f := range fields {
+			optional := ""
+			if f.optional {
+				optional = "?"
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", f.name, optional, tsType(f.t))
+		}
+		b.WriteString("};\n")
+	}
+
+	b.WriteString("\nexport type Envelope<T extends string, M> = {\n")
+	b.WriteString("  Version: number;\n")
+	b.WriteString("  MessageType: T;\n")
+	b.WriteString("  Message: M;\n")
+	b.WriteString("};\n")
+
+	b.WriteString("\nexport type ServerMessage =\n")
+	for i, event := range Event.All {
+		end := ""
+		if i == len(Event.All)-1 {
+			end = ";"
+		}
+		fmt.Fprintf(&b, "  | Envelope<%q, %s>%s\n", event.Type(), typeName(reflect.TypeOf(event)), end)
+	}
+
+	return []byte(b.String())
+}
+
+func tsType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return tsType(t.Elem()) + " | null"
+	case reflect.Struct:
+		return typeName(t)
+	case reflect.Slice, reflect.Array:
+		return tsType(deref(t.Elem())) + "[] | null"
+	case reflect.Map:
+		return fmt.Sprintf("Record<string, %s> | null", tsType(deref(t.Elem())))
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "unknown"
+	}
+}
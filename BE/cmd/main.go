@@ -23,7 +23,7 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
-	container := bootstrap.Register(appContext)
+	container := bootstrap.Register(appContext, config)
 
 	routes, err := CompositionRoot.CreateEndPoints(container, config)
 	if err != nil {
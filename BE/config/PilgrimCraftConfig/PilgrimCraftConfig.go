@@ -12,8 +12,10 @@ import (
 )
 
 type PilgrimCraftConfig struct {
-	Server              ServerConfig `mapstructure:"SERVER"`
-	MinimumLoggingLevel slog.Level   `mapstructure:"MIN_LOGGING_LEVEL"`
+	Server              ServerConfig  `mapstructure:"SERVER"`
+	Hub                 HubConfig     `mapstructure:"HUB"`
+	Classes             ClassesConfig `mapstructure:"CLASSES"`
+	MinimumLoggingLevel slog.Level    `mapstructure:"MIN_LOGGING_LEVEL"`
 }
 
 type ServerConfig struct {
@@ -21,6 +23,32 @@ type ServerConfig struct {
 	Port string `mapstructure:"PORT"`
 }
 
+// Origin is where the front end is served from, the one origin the API
+// answers.
+func (c ServerConfig) Origin() string {
+	return fmt.Sprintf("%s:%s", c.Host, "3000")
+}
+
+const (
+	HubBackendMemory = "memory"
+	HubBackendRedis  = "redis"
+)
+
+// HubConfig selects the pub/sub backend. The in-memory hub only works with a
+// single instance; the redis hub lets several instances share subscribers.
+type HubConfig struct {
+	Backend       string `mapstructure:"BACKEND"`
+	RedisAddr     string `mapstructure:"REDIS_ADDR"`
+	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
+	RedisDB       int    `mapstructure:"REDIS_DB"`
+}
+
+// ClassesConfig points to a JSON file replacing the classes shipped with the
+// game. Empty keeps the shipped ones.
+type ClassesConfig struct {
+	File string `mapstructure:"FILE"`
+}
+
 func LoadSettings(ctx context.Context) *PilgrimCraftConfig {
 	viper.SetConfigName(".env")
 	viper.SetConfigType("env")
@@ -0,0 +1,49 @@
+package Ability
+
+type Enum string
+
+const (
+	// Shield redirects the attacks on an adjacent ally to the user this turn.
+	Shield Enum = "Shield"
+	// Steal takes the bag of an adjacent enemy without a fight.
+	Steal Enum = "Steal"
+	// Volley attacks every enemy on a tile within the range of the user.
+	Volley Enum = "Volley"
+)
+
+type Struct struct {
+	Name Enum `json:"Name"`
+	// Cooldown is the number of turns following its use the ability cannot
+	// be used again.
+	Cooldown int `json:"Cooldown"`
+	// NeedsTarget tells whether the ability aims at a player rather than a tile.
+	NeedsTarget bool `json:"NeedsTarget"`
+}
+
+// All lists the abilities in the order they resolve: shields first so they
+// protect from the steals and volleys of the same turn.
+var All = []Struct{
+	{Shield, 1, true},
+	{Steal, 2, true},
+	{Volley, 2, false},
+}
+
+// Get returns the ability with the given name.
+func Get(name Enum) (Struct, bool) {
+	for _, ability := range All {
+		if ability.Name == name {
+			return ability, true
+		}
+	}
+	return Struct{}, false
+}
+
+// Order is the rank of the ability in All, used to resolve them in turn.
+func Order(name Enum) int {
+	for i, ability := range All {
+		if ability.Name == name {
+			return i
+		}
+	}
+	return len(All)
+}
@@ -0,0 +1,127 @@
+package Action
+
+import (
+	"ChoHanJi/domain/Player"
+	"slices"
+)
+
+// NoTeam excludes no team from a draw.
+const NoTeam = -1
+
+// Bracket holds the fighters of every team sharing a tile. A champion holds
+// the tile against challengers from the other teams, whoever they are, until a
+// single team is left standing.
+type Bracket struct {
+	teams    []int
+	fighters map[int][]Player.Id
+	alive    func(Player.Id) bool
+	pick     func(n int) (int, error)
+}
+
+// NewBracket buckets the living players by team, in the order of the team
+// numbers, and draws with pick, which returns an index below n.
+func NewBracket(players []*Player.Struct, alive func(Player.Id) bool, pick func(n int) (int, error)) *Bracket {
+	b := &Bracket{fighters: make(map[int][]Player.Id), alive: alive, pick: pick}
+	for _, pl := range players {
+		if !alive(pl.Id) {
+			continue
+		}
+		if _, found := b.fighters[pl.TeamNumber]; !found {
+			b.teams = append(b.teams, pl.TeamNumber)
+		}
+		b.fighters[pl.TeamNumber] = append(b.fighters[pl.TeamNumber], pl.Id)
+	}
+	slices.Sort(b.teams)
+	return b
+}
+
+// Contested reports whether fighters of two teams at least are left.
+func (b *Bracket) Contested() bool {
+	return b.teamsLeft(NoTeam) > 1
+}
+
+// Run fights the tile out. A champion drawn among every team holds it against
+// challengers drawn from the other teams, fight settling each bout. A
+// challenger killing the champion takes the tile over, one who neither wins
+// nor dies is out all the same. A champion dying without a successor leaves
+// the tile to whoever is drawn next.
+func (b *Bracket) Run(fight func(champ, challenger Player.Id) error) error {
+	var champ Player.Id
+	champTeam := NoTeam
+	for {
+		if champTeam == NoTeam || !b.alive(champ) {
+			if !b.Contested() {
+				return nil
+			}
+			var err error
+			if champ, champTeam, _, err = b.Draw(NoTeam); err != nil {
+				return err
+			}
+			continue
+		}
+
+		challenger, challengerTeam, found, err := b.Draw(champTeam)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return nil
+		}
+		if !b.alive(challenger) {
+			continue
+		}
+
+		if err := fight(champ, challenger); err != nil {
+			return err
+		}
+
+		if b.alive(challenger) && !b.alive(champ) {
+			champ, champTeam = challenger, challengerTeam
+		}
+	}
+}
+
+func (b *Bracket) teamsLeft(exclude int) int {
+	n := 0
+	for _, team := range b.teams {
+		if team != exclude && len(b.fighters[team]) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Draw takes a random fighter out of the teams other than exclude, every
+// fighter being as likely as the others. ok is false when none is left.
+func (b *Bracket) Draw(exclude int) (id Player.Id, team int, ok bool, err error) {
+	total := 0
+	for _, t := range b.teams {
+		if t != exclude {
+			total += len(b.fighters[t])
+		}
+	}
+	if total == 0 {
+		return "", 0, false, nil
+	}
+
+	idx, err := b.pick(total)
+	if err != nil {
+		return "", 0, false, err
+	}
+
+	for _, t := range b.teams {
+		if t == exclude {
+			continue
+		}
+		list := b.fighters[t]
+		if idx >= len(list) {
+			idx -= len(list)
+			continue
+		}
+		id = list[idx]
+		b.fighters[t] = slices.Delete(list, idx, idx+1)
+		return id, t, true, nil
+	}
+
+	return "", 0, false, nil
+}
@@ -0,0 +1,181 @@
+package Action
+
+import (
+	"ChoHanJi/domain/Player"
+	"slices"
+	"testing"
+)
+
+type bout struct {
+	champ      Player.Id
+	challenger Player.Id
+}
+
+func TestBracketRun(t *testing.T) {
+	player := func(id Player.Id, team int) *Player.Struct {
+		return &Player.Struct{Id: id, TeamNumber: team}
+	}
+	first := func(int) (int, error) { return 0, nil }
+	last := func(n int) (int, error) { return n - 1, nil }
+
+	tests := []struct {
+		name    string
+		players []*Player.Struct
+		dead    []Player.Id
+		pick    func(int) (int, error)
+		// losers names who dies in each bout: the champion, the challenger,
+		// both or neither.
+		losers map[bout][]Player.Id
+		want   []bout
+	}{
+		{
+			name:    "teammates do not fight",
+			players: []*Player.Struct{player("a", 1), player("b", 1)},
+			pick:    first,
+		},
+		{
+			name:    "two teams, whatever their numbers",
+			players: []*Player.Struct{player("a", 4), player("b", 6)},
+			pick:    first,
+			losers:  map[bout][]Player.Id{{"a", "b"}: {"b"}},
+			want:    []bout{{"a", "b"}},
+		},
+		{
+			name:    "the champion holds against three teams",
+			players: []*Player.Struct{player("c", 3), player("a", 1), player("b", 2)},
+			pick:    first,
+			losers: map[bout][]Player.Id{
+				{"a", "b"}: {"b"},
+				{"a", "c"}: {"c"},
+			},
+			want: []bout{{"a", "b"}, {"a", "c"}},
+		},
+		{
+			name:    "a challenger killing the champion takes over",
+			players: []*Player.Struct{player("a", 1), player("b", 2), player("c", 3)},
+			pick:    first,
+			losers: map[bout][]Player.Id{
+				{"a", "b"}: {"a"},
+				{"b", "c"}: {"c"},
+			},
+			want: []bout{{"a", "b"}, {"b", "c"}},
+		},
+		{
+			name:    "the new champion's teammates are left alone",
+			players: []*Player.Struct{player("a", 1), player("b", 2), player("d", 2), player("c", 3)},
+			pick:    first,
+			losers: map[bout][]Player.Id{
+				{"a", "b"}: {"a"},
+				{"b", "c"}: {"c"},
+			},
+			want: []bout{{"a", "b"}, {"b", "c"}},
+		},
+		{
+			name:    "both dying leaves the tile to the next draw",
+			players: []*Player.Struct{player("a", 1), player("b", 2), player("c", 3), player("d", 4)},
+			pick:    first,
+			losers: map[bout][]Player.Id{
+				{"a", "b"}: {"a", "b"},
+				{"c", "d"}: {"d"},
+			},
+			want: []bout{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:    "a challenger who neither wins nor dies is out",
+			players: []*Player.Struct{player("a", 1), player("b", 2), player("c", 3)},
+			pick:    first,
+			losers: map[bout][]Player.Id{
+				{"a", "c"}: {"c"},
+			},
+			want: []bout{{"a", "b"}, {"a", "c"}},
+		},
+		{
+			name:    "free-for-all",
+			players: []*Player.Struct{player("a", 1), player("b", 2), player("c", 3), player("d", 4), player("e", 5), player("f", 6)},
+			pick:    last,
+			losers: map[bout][]Player.Id{
+				{"f", "e"}: {"f"},
+				{"e", "d"}: {"d"},
+				{"e", "c"}: {"e"},
+				{"c", "b"}: {"b"},
+				{"c", "a"}: {"a"},
+			},
+			want: []bout{{"f", "e"}, {"e", "d"}, {"e", "c"}, {"c", "b"}, {"c", "a"}},
+		},
+		{
+			name:    "the dead do not fight",
+			players: []*Player.Struct{player("a", 1), player("b", 2), player("c", 3)},
+			dead:    []Player.Id{"b"},
+			pick:    first,
+			losers:  map[bout][]Player.Id{{"a", "c"}: {"c"}},
+			want:    []bout{{"a", "c"}},
+		},
+		{
+			name:    "a lone survivor has no one to fight",
+			players: []*Player.Struct{player("a", 1), player("b", 2)},
+			dead:    []Player.Id{"b"},
+			pick:    first,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dead := make(map[Player.Id]bool)
+			for _, id := range tt.dead {
+				dead[id] = true
+			}
+			alive := func(id Player.Id) bool { return !dead[id] }
+
+			var got []bout
+			err := NewBracket(tt.players, alive, tt.pick).Run(func(champ, challenger Player.Id) error {
+				b := bout{champ, challenger}
+				got = append(got, b)
+				for _, id := range tt.losers[b] {
+					dead[id] = true
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Run() fought %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBracketDrawExcludesTeam(t *testing.T) {
+	players := []*Player.Struct{
+		{Id: "a", TeamNumber: 1},
+		{Id: "b", TeamNumber: 2},
+		{Id: "c", TeamNumber: 1},
+		{Id: "d", TeamNumber: 3},
+	}
+
+	for idx := range 2 {
+		pick := func(n int) (int, error) {
+			if n != 2 {
+				t.Fatalf("pick(%d), want 2 fighters outside team 1", n)
+			}
+			return idx, nil
+		}
+
+		bracket := NewBracket(players, func(Player.Id) bool { return true }, pick)
+		id, team, ok, err := bracket.Draw(1)
+		if err != nil || !ok {
+			t.Fatalf("Draw(1) = %v, %v, want a fighter", ok, err)
+		}
+		if team == 1 || id == "a" || id == "c" {
+			t.Fatalf("Draw(1) = %s of team %d, want no fighter of team 1", id, team)
+		}
+	}
+
+	bracket := NewBracket(players[:1], func(Player.Id) bool { return true }, func(int) (int, error) {
+		t.Fatal("pick called without a fighter to draw")
+		return 0, nil
+	})
+	if _, _, ok, err := bracket.Draw(1); ok || err != nil {
+		t.Fatalf("Draw(1) = %v, %v, want no fighter", ok, err)
+	}
+}
@@ -0,0 +1,87 @@
+package Action
+
+import (
+	"ChoHanJi/domain/Movement"
+	"ChoHanJi/domain/Player"
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// Conflict is a pair of enemies whose moves meet before the end of the turn,
+// either by swapping tiles or by crossing each other on the way. Passing
+// through the tile of an enemy standing still is a crossing too.
+type Conflict struct {
+	Step   int
+	First  Player.Id
+	Second Player.Id
+	X      int
+	Y      int
+}
+
+// FindConflicts treats the routes as simultaneous: every player advances one
+// tile per step from where they stand and stays on their destination once
+// reached. The players without a route stand still, so only the ones able to
+// fight should be given. Enemies ending on the same tile are left to the
+// collision resolution. The conflicts are ordered by step, then by player
+// ids, so they do not depend on the order the moves were submitted in. Each
+// pair conflicts at most once.
+func FindConflicts(routes map[Player.Id][]Movement.Position, players map[Player.Id]*Player.Struct) []Conflict {
+	ids := slices.Sorted(maps.Keys(players))
+
+	paths := make([][]Movement.Position, len(ids))
+	for i, id := range ids {
+		player := players[id]
+		paths[i] = append([]Movement.Position{{X: player.X, Y: player.Y}}, routes[id]...)
+	}
+
+	var conflicts []Conflict
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			first, second := players[ids[i]], players[ids[j]]
+			if first.TeamNumber == second.TeamNumber || (len(paths[i]) == 1 && len(paths[j]) == 1) {
+				continue
+			}
+
+			if conflict, found := findConflict(paths[i], paths[j]); found {
+				conflict.First, conflict.Second = first.Id, second.Id
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	slices.SortFunc(conflicts, func(a, b Conflict) int {
+		return cmp.Or(
+			cmp.Compare(a.Step, b.Step),
+			cmp.Compare(a.First, b.First),
+			cmp.Compare(a.Second, b.Second),
+		)
+	})
+
+	return conflicts
+}
+
+func findConflict(a, b []Movement.Position) (Conflict, bool) {
+	endA, endB := a[len(a)-1], b[len(b)-1]
+
+	for step := 1; step < max(len(a), len(b)); step++ {
+		a0, a1 := at(a, step-1), at(a, step)
+		b0, b1 := at(b, step-1), at(b, step)
+
+		// Swapping tiles: they meet on the way.
+		if a0 != a1 && a0 == b1 && a1 == b0 {
+			return Conflict{Step: step, X: a0.X, Y: a0.Y}, true
+		}
+
+		// Meeting on a tile one of them only passes through.
+		if a1 == b1 && (a1 != endA || a1 != endB) {
+			return Conflict{Step: step, X: a1.X, Y: a1.Y}, true
+		}
+	}
+
+	return Conflict{}, false
+}
+
+func at(path []Movement.Position, step int) Movement.Position {
+	return path[min(step, len(path)-1)]
+}
@@ -0,0 +1,117 @@
+package Action
+
+import (
+	"ChoHanJi/domain/Movement"
+	"ChoHanJi/domain/Player"
+	"slices"
+	"testing"
+)
+
+func TestFindConflicts(t *testing.T) {
+	player := func(id Player.Id, team, x, y int) *Player.Struct {
+		return &Player.Struct{Id: id, TeamNumber: team, X: x, Y: y}
+	}
+	route := func(positions ...Movement.Position) []Movement.Position {
+		return positions
+	}
+	pos := func(x, y int) Movement.Position {
+		return Movement.Position{X: x, Y: y}
+	}
+
+	tests := []struct {
+		name    string
+		players []*Player.Struct
+		routes  map[Player.Id][]Movement.Position
+		want    []Conflict
+	}{
+		{
+			name:    "enemies swapping tiles",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 2, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0)), "b": route(pos(0, 0))},
+			want:    []Conflict{{Step: 1, First: "a", Second: "b", X: 0, Y: 0}},
+		},
+		{
+			name:    "teammates swapping tiles",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 1, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0)), "b": route(pos(0, 0))},
+		},
+		{
+			name:    "enemies crossing on the way",
+			players: []*Player.Struct{player("a", 1, 0, 1), player("b", 2, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 1), pos(2, 1)), "b": route(pos(1, 1), pos(1, 2))},
+			want:    []Conflict{{Step: 1, First: "a", Second: "b", X: 1, Y: 1}},
+		},
+		{
+			name:    "teammates crossing on the way",
+			players: []*Player.Struct{player("a", 1, 0, 1), player("b", 1, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 1), pos(2, 1)), "b": route(pos(1, 1), pos(1, 2))},
+		},
+		{
+			name:    "crossing where a shorter route already ended",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 2, 2, 1)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0), pos(2, 0), pos(3, 0)), "b": route(pos(2, 0))},
+			want:    []Conflict{{Step: 2, First: "a", Second: "b", X: 2, Y: 0}},
+		},
+		{
+			name:    "enemies ending on the same tile",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 2, 2, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0)), "b": route(pos(1, 0))},
+		},
+		{
+			name:    "following an enemy",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 2, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0)), "b": route(pos(2, 0))},
+		},
+		{
+			name:    "passing through a stationary enemy",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 2, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0), pos(2, 0))},
+			want:    []Conflict{{Step: 1, First: "a", Second: "b", X: 1, Y: 0}},
+		},
+		{
+			name:    "stopping on a stationary enemy",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 2, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0))},
+		},
+		{
+			name:    "passing through a stationary teammate",
+			players: []*Player.Struct{player("a", 1, 0, 0), player("b", 1, 1, 0)},
+			routes:  map[Player.Id][]Movement.Position{"a": route(pos(1, 0), pos(2, 0))},
+		},
+		{
+			name: "ordered by step then ids",
+			players: []*Player.Struct{
+				player("a", 1, 0, 0), player("b", 2, 2, 0),
+				player("c", 1, 0, 5), player("d", 2, 1, 5),
+				player("e", 3, 3, 0),
+			},
+			routes: map[Player.Id][]Movement.Position{
+				"a": route(pos(1, 0), pos(2, 0), pos(3, 0)),
+				"c": route(pos(1, 5)),
+				"d": route(pos(0, 5)),
+			},
+			want: []Conflict{
+				{Step: 1, First: "c", Second: "d", X: 0, Y: 5},
+				{Step: 2, First: "a", Second: "b", X: 2, Y: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The maps are walked in a random order, which stands for the
+			// order the moves were submitted in.
+			for range 20 {
+				players := make(map[Player.Id]*Player.Struct, len(tt.players))
+				for _, p := range tt.players {
+					players[p.Id] = p
+				}
+
+				got := FindConflicts(tt.routes, players)
+				if !slices.Equal(got, tt.want) {
+					t.Fatalf("FindConflicts() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -7,4 +7,9 @@ const (
 	Attack
 	BonusAttack
 	Skip
+	Withdraw
+	Ability
+	Drop
+	Use
+	Raid
 )
@@ -1,21 +1,47 @@
 package Action
 
 import (
+	a "ChoHanJi/domain/Ability"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Item"
+	"ChoHanJi/domain/Movement"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/Room"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 )
 
+var (
+	// ErrNotFound is returned when the room has no action list.
+	ErrNotFound = Failure.New(Failure.NotFound, "not found")
+	// ErrInvalidCombination is returned when an action cannot be combined with
+	// the actions the player already submitted this turn.
+	ErrInvalidCombination = Failure.New(Failure.Invalid, "invalid combination of actions")
+)
+
 type List struct {
 	lock sync.Mutex
 	al   map[Room.Id]*actionList
 }
 
+// actionList holds a single submission per player and per turn.
 type actionList struct {
-	AttackList      []AttackStruct
-	MoveList        []MoveStruct
-	BonusAttackList []BonusAttackStruct
+	Submissions map[Player.Id]*submission
+}
+
+// submission is either an Attack, a Move optionally followed by a BonusAttack,
+// an Ability, a Drop, a Use, a Raid or a Skip.
+type submission struct {
+	Attack      *AttackStruct
+	Move        *MoveStruct
+	BonusAttack *BonusAttackStruct
+	Ability     *AbilityStruct
+	Drop        *ItemActionStruct
+	Use         *ItemActionStruct
+	Raid        *RaidStruct
+	Skip        bool
 }
 
 func New() *List {
@@ -29,9 +55,7 @@ func (s *List) StartGame(roomId Room.Id) {
 	defer s.lock.Unlock()
 	if _, found := s.al[roomId]; !found {
 		room := &actionList{
-			make([]AttackStruct, 0),
-			make([]MoveStruct, 0),
-			make([]BonusAttackStruct, 0),
+			make(map[Player.Id]*submission),
 		}
 		s.al[roomId] = room
 	}
@@ -43,25 +67,42 @@ func (s *List) Reset(roomId Room.Id) error {
 
 	room, found := s.al[roomId]
 	if !found {
-		return fmt.Errorf("ActionList.Reset: room not found")
+		return fmt.Errorf("ActionList.Reset: %s %w", "room", ErrNotFound)
 	}
-	room.AttackList = room.AttackList[:0]
-	room.MoveList = room.MoveList[:0]
-	room.BonusAttackList = room.BonusAttackList[:0]
+	room.Submissions = make(map[Player.Id]*submission)
 
 	return nil
 }
 
+// sortedSubmissions returns the submissions ordered by player id, so that the
+// turn resolves the same way whatever order the players submitted in.
+func (room *actionList) sortedSubmissions() []*submission {
+	ids := slices.Sorted(maps.Keys(room.Submissions))
+
+	submissions := make([]*submission, 0, len(ids))
+	for _, id := range ids {
+		submissions = append(submissions, room.Submissions[id])
+	}
+	return submissions
+}
+
 func (s *List) GetAttackActionList(roomId Room.Id) ([]AttackStruct, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	room, found := s.al[roomId]
 	if !found {
-		return nil, fmt.Errorf("ActionList.GetAttackActionList: room not found")
+		return nil, fmt.Errorf("ActionList.GetAttackActionList: %s %w", "room", ErrNotFound)
+	}
+
+	attacks := make([]AttackStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.Attack != nil {
+			attacks = append(attacks, *submission.Attack)
+		}
 	}
 
-	return room.AttackList, nil
+	return attacks, nil
 }
 
 func (s *List) GetMoveActionList(roomId Room.Id) ([]MoveStruct, error) {
@@ -70,10 +111,17 @@ func (s *List) GetMoveActionList(roomId Room.Id) ([]MoveStruct, error) {
 
 	room, found := s.al[roomId]
 	if !found {
-		return nil, fmt.Errorf("ActionList.GetMoveActionList: room not found")
+		return nil, fmt.Errorf("ActionList.GetMoveActionList: %s %w", "room", ErrNotFound)
 	}
 
-	return room.MoveList, nil
+	moves := make([]MoveStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.Move != nil {
+			moves = append(moves, *submission.Move)
+		}
+	}
+
+	return moves, nil
 }
 
 func (s *List) GetBonusAttackList(roomId Room.Id) ([]BonusAttackStruct, error) {
@@ -82,30 +130,78 @@ func (s *List) GetBonusAttackList(roomId Room.Id) ([]BonusAttackStruct, error) {
 
 	room, found := s.al[roomId]
 	if !found {
-		return nil, fmt.Errorf("ActionList.GetBonusAttackList: room not found")
+		return nil, fmt.Errorf("ActionList.GetBonusAttackList: %s %w", "room", ErrNotFound)
+	}
+
+	bonusAttacks := make([]BonusAttackStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.BonusAttack != nil {
+			bonusAttacks = append(bonusAttacks, *submission.BonusAttack)
+		}
 	}
 
-	return room.BonusAttackList, nil
+	return bonusAttacks, nil
 }
 
+// MoveStruct goes from PrevX, PrevY to X, Y. Path lists the tiles entered on
+// the way, X, Y included. Without a path the move goes straight.
 type MoveStruct struct {
-	X     int       `json:"X" validate:"gte=0"`
-	Y     int       `json:"Y" validate:"gte=0"`
-	PrevX int       `json:"PrevX" validate:"gte=0"`
-	PrevY int       `json:"PrevY" validate:"gte=0"`
-	Id    Player.Id `json:"Id" validate:"required,alphanum,len=5"`
+	X     int                 `json:"X" validate:"gte=0"`
+	Y     int                 `json:"Y" validate:"gte=0"`
+	PrevX int                 `json:"PrevX" validate:"gte=0"`
+	PrevY int                 `json:"PrevY" validate:"gte=0"`
+	Path  []Movement.Position `json:"Path,omitempty" validate:"omitempty,dive"`
+	Id    Player.Id           `json:"Id" validate:"required,alphanum,len=5"`
+	// Extend appends the move to the one submitted before instead of
+	// replacing it, so a path sent step by step keeps its original start.
+	Extend bool `json:"Extend,omitempty"`
 }
 
-func (s *List) SubmitMoveAction(roomId Room.Id, x, y, prevX, prevY int, id Player.Id) error {
+// Route returns the tiles entered by the move, the start excluded.
+func (m MoveStruct) Route() []Movement.Position {
+	if len(m.Path) > 0 {
+		return slices.Clone(m.Path)
+	}
+	return Movement.Straight(Movement.Position{X: m.PrevX, Y: m.PrevY}, Movement.Position{X: m.X, Y: m.Y})
+}
+
+// SubmitMoveAction replaces any Attack or Skip of the player. An extending
+// move must start where the previous move of the player ended and is
+// appended to it, any other move replaces the previous one. Either way the
+// bonus attack, aimed from where the previous move ended, is dropped. check
+// vets the move as it will be played, the extended part included, before it
+// is kept.
+func (s *List) SubmitMoveAction(roomId Room.Id, x, y, prevX, prevY int, path []Movement.Position, id Player.Id, extend bool, check func(MoveStruct) error) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	room, found := s.al[roomId]
 	if !found {
-		return fmt.Errorf("ActionList.SubmitMoveAction: room not found")
+		return fmt.Errorf("ActionList.SubmitMoveAction: %s %w", "room", ErrNotFound)
 	}
 
-	room.MoveList = append(room.MoveList, MoveStruct{x, y, prevX, prevY, id})
+	current, found := room.Submissions[id]
+	hasMove := found && current.Move != nil
+
+	move := MoveStruct{X: x, Y: y, PrevX: prevX, PrevY: prevY, Path: slices.Clone(path), Id: id}
+	if extend {
+		if !hasMove || current.Move.X != prevX || current.Move.Y != prevY {
+			return fmt.Errorf("ActionList.SubmitMoveAction: %w: no move ending at %d, %d to extend", ErrInvalidCombination, prevX, prevY)
+		}
+		move.Path = append(current.Move.Route(), move.Route()...)
+		move.PrevX, move.PrevY = current.Move.PrevX, current.Move.PrevY
+	}
+	if err := check(move); err != nil {
+		return fmt.Errorf("ActionList.SubmitMoveAction: %w", err)
+	}
+
+	if !hasMove {
+		current = &submission{}
+		room.Submissions[id] = current
+	}
+
+	current.Move = &move
+	current.BonusAttack = nil
 
 	return nil
 }
@@ -115,16 +211,17 @@ type AttackStruct struct {
 	DefenderId Player.Id `json:"DefenderId" validate:"required,alphanum,len=5"`
 }
 
+// SubmitAttackAction replaces whatever the attacker submitted this turn.
 func (s *List) SubmitAttackAction(roomId Room.Id, attackerId, defenderId Player.Id) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	room, found := s.al[roomId]
 	if !found {
-		return fmt.Errorf("ActionList.SubmitAttackAction: room not found")
+		return fmt.Errorf("ActionList.SubmitAttackAction: %s %w", "room", ErrNotFound)
 	}
 
-	room.AttackList = append(room.AttackList, AttackStruct{attackerId, defenderId})
+	room.Submissions[attackerId] = &submission{Attack: &AttackStruct{attackerId, defenderId}}
 
 	return nil
 }
@@ -135,20 +232,236 @@ type BonusAttackStruct struct {
 	Id Player.Id `json:"Id" validate:"required,alphanum,len=5"`
 }
 
+// SubmitBonusAttackAction sets the bonus attack following the move of the
+// attacker, replacing the previous one. It requires a move.
 func (s *List) SubmitBonusAttackAction(roomId Room.Id, x, y int, attackerId Player.Id) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	room, found := s.al[roomId]
 	if !found {
-		return fmt.Errorf("ActionList.SubmitAttackAction: room not found")
+		return fmt.Errorf("ActionList.SubmitBonusAttackAction: %s %w", "room", ErrNotFound)
+	}
+
+	current, found := room.Submissions[attackerId]
+	if !found || current.Move == nil {
+		return fmt.Errorf("ActionList.SubmitBonusAttackAction: %w: a bonus attack must follow a move", ErrInvalidCombination)
 	}
 
-	room.BonusAttackList = append(room.BonusAttackList, BonusAttackStruct{x, y, attackerId})
+	current.BonusAttack = &BonusAttackStruct{x, y, attackerId}
 
 	return nil
 }
 
+// RaidStruct scatters the items of the treasure chest of the enemy Team.
+type RaidStruct struct {
+	Id   Player.Id `json:"Id" validate:"required,alphanum,len=5"`
+	Team int       `json:"Team" validate:"required,gt=0"`
+}
+
+// SubmitRaidAction replaces whatever the raider submitted this turn.
+func (s *List) SubmitRaidAction(roomId Room.Id, raid RaidStruct) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return fmt.Errorf("ActionList.SubmitRaidAction: %s %w", "room", ErrNotFound)
+	}
+
+	room.Submissions[raid.Id] = &submission{Raid: &raid}
+
+	return nil
+}
+
+func (s *List) GetRaidList(roomId Room.Id) ([]RaidStruct, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return nil, fmt.Errorf("ActionList.GetRaidList: %s %w", "room", ErrNotFound)
+	}
+
+	raids := make([]RaidStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.Raid != nil {
+			raids = append(raids, *submission.Raid)
+		}
+	}
+
+	return raids, nil
+}
+
+// AbilityStruct uses an ability on a player, or on a tile when the ability
+// needs no target.
+type AbilityStruct struct {
+	Id       Player.Id `json:"Id" validate:"required,alphanum,len=5"`
+	Ability  a.Enum    `json:"Ability" validate:"required"`
+	TargetId Player.Id `json:"TargetId,omitempty" validate:"omitempty,alphanum,len=5"`
+	X        int       `json:"X" validate:"gte=0"`
+	Y        int       `json:"Y" validate:"gte=0"`
+}
+
+// SubmitAbilityAction replaces whatever the player submitted this turn.
+func (s *List) SubmitAbilityAction(roomId Room.Id, ability AbilityStruct) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return fmt.Errorf("ActionList.SubmitAbilityAction: %s %w", "room", ErrNotFound)
+	}
+
+	room.Submissions[ability.Id] = &submission{Ability: &ability}
+
+	return nil
+}
+
+// GetAbilityList returns the abilities in the order they resolve, then by player id.
+func (s *List) GetAbilityList(roomId Room.Id) ([]AbilityStruct, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return nil, fmt.Errorf("ActionList.GetAbilityList: %s %w", "room", ErrNotFound)
+	}
+
+	abilities := make([]AbilityStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.Ability != nil {
+			abilities = append(abilities, *submission.Ability)
+		}
+	}
+	slices.SortStableFunc(abilities, func(x, y AbilityStruct) int {
+		return a.Order(x.Ability) - a.Order(y.Ability)
+	})
+
+	return abilities, nil
+}
+
+// ItemActionStruct drops or uses an item of the inventory of the player.
+type ItemActionStruct struct {
+	Id     Player.Id `json:"Id" validate:"required,alphanum,len=5"`
+	ItemId Item.Id   `json:"ItemId" validate:"required,alphanum,len=5"`
+}
+
+// SubmitDropAction replaces whatever the player submitted this turn.
+func (s *List) SubmitDropAction(roomId Room.Id, drop ItemActionStruct) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return fmt.Errorf("ActionList.SubmitDropAction: %s %w", "room", ErrNotFound)
+	}
+
+	room.Submissions[drop.Id] = &submission{Drop: &drop}
+
+	return nil
+}
+
+// SubmitUseAction replaces whatever the player submitted this turn.
+func (s *List) SubmitUseAction(roomId Room.Id, use ItemActionStruct) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return fmt.Errorf("ActionList.SubmitUseAction: %s %w", "room", ErrNotFound)
+	}
+
+	room.Submissions[use.Id] = &submission{Use: &use}
+
+	return nil
+}
+
+func (s *List) GetDropList(roomId Room.Id) ([]ItemActionStruct, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return nil, fmt.Errorf("ActionList.GetDropList: %s %w", "room", ErrNotFound)
+	}
+
+	drops := make([]ItemActionStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.Drop != nil {
+			drops = append(drops, *submission.Drop)
+		}
+	}
+
+	return drops, nil
+}
+
+func (s *List) GetUseList(roomId Room.Id) ([]ItemActionStruct, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return nil, fmt.Errorf("ActionList.GetUseList: %s %w", "room", ErrNotFound)
+	}
+
+	uses := make([]ItemActionStruct, 0)
+	for _, submission := range room.sortedSubmissions() {
+		if submission.Use != nil {
+			uses = append(uses, *submission.Use)
+		}
+	}
+
+	return uses, nil
+}
+
 type SkipStruct struct {
 	Id Player.Id `json:"Id" validate:"required,alphanum,len=5"`
 }
+
+// SubmitSkipAction replaces whatever the player submitted this turn.
+func (s *List) SubmitSkipAction(roomId Room.Id, id Player.Id) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return fmt.Errorf("ActionList.SubmitSkipAction: %s %w", "room", ErrNotFound)
+	}
+
+	room.Submissions[id] = &submission{Skip: true}
+
+	return nil
+}
+
+type WithdrawStruct struct {
+	Id Player.Id `json:"Id" validate:"required,alphanum,len=5"`
+}
+
+// WithdrawAction removes the submission of the player, if any.
+func (s *List) WithdrawAction(roomId Room.Id, id Player.Id) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return fmt.Errorf("ActionList.WithdrawAction: %s %w", "room", ErrNotFound)
+	}
+
+	delete(room.Submissions, id)
+
+	return nil
+}
+
+// GetSubmittedPlayers returns the players who submitted an action, skips included, since the last reset.
+func (s *List) GetSubmittedPlayers(roomId Room.Id) ([]Player.Id, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	room, found := s.al[roomId]
+	if !found {
+		return nil, fmt.Errorf("ActionList.GetSubmittedPlayers: %s %w", "room", ErrNotFound)
+	}
+
+	return slices.Sorted(maps.Keys(room.Submissions)), nil
+}
@@ -1,20 +1,27 @@
 package Action
 
 import (
+	a "ChoHanJi/domain/Ability"
+	"ChoHanJi/domain/Cooldown"
 	"ChoHanJi/domain/Death"
+	"ChoHanJi/domain/Event"
 	"ChoHanJi/domain/Fight"
 	"ChoHanJi/domain/Game"
+	"ChoHanJi/domain/Item"
+	m "ChoHanJi/domain/Map"
+	"ChoHanJi/domain/Movement"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/PlayerBlocker"
 	"ChoHanJi/domain/Room"
 	"ChoHanJi/domain/Team"
 	"ChoHanJi/domain/TileFlag"
 	"ChoHanJi/domain/UpdateMessage"
-	"ChoHanJi/driven/sse/SSEHub"
+	"ChoHanJi/domain/Visibility"
+	HubPorts "ChoHanJi/driven/ports"
 	crand "crypto/rand"
-	"encoding/json"
 	"errors"
 	"math/big"
+	"slices"
 )
 
 type CurrentFights interface {
@@ -24,8 +31,10 @@ type CurrentFights interface {
 var _ CurrentFights = (*Fight.CurrentFights)(nil)
 
 type DeathList interface {
+	PronounceDead(roomId Room.Id, playerId Player.Id)
 	CheckIfDead(roomId Room.Id, playerId Player.Id) bool
 	GetListOfDead(roomId Room.Id) []Player.Id
+	Revive(roomId Room.Id, playerId Player.Id)
 	Reset(roomId Room.Id)
 }
 
@@ -41,12 +50,21 @@ type IPlayerBlocker interface {
 
 var _ IPlayerBlocker = (*PlayerBlocker.Struct)(nil)
 
+type ICooldowns interface {
+	Start(roomId Room.Id, playerId Player.Id, ability a.Enum, turns int)
+	Remaining(roomId Room.Id, playerId Player.Id, ability a.Enum) int
+	Tick(roomId Room.Id)
+}
+
+var _ ICooldowns = (*Cooldown.List)(nil)
+
 type IHub interface {
-	Publish(roomId, adminId, messageType, messageBody string) error
-	PublishToAll(roomId, messageType, messageBody string) error
+	Publish(roomId, subscriberId string, event Event.Interface) error
+	PublishToAll(roomId string, event Event.Interface) error
+	PublishToSpectators(roomId string, event Event.Interface) error
 }
 
-var _ IHub = (*SSEHub.Struct)(nil)
+var _ IHub = (HubPorts.HubInterface)(nil)
 
 type Processor struct {
 	r   Room.Rooms
@@ -54,17 +72,21 @@ type Processor struct {
 	dl  DeathList
 	pb  IPlayerBlocker
 	hub IHub
+	cd  ICooldowns
 }
 
-func NewProcessor(r Room.Rooms, cf CurrentFights, dl DeathList, pb IPlayerBlocker, hub IHub) *Processor {
-	return &Processor{r, cf, dl, pb, hub}
+func NewProcessor(r Room.Rooms, cf CurrentFights, dl DeathList, pb IPlayerBlocker, hub IHub, cd ICooldowns) *Processor {
+	return &Processor{r, cf, dl, pb, hub, cd}
 }
 
-func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []MoveStruct, bonusAttacks []BonusAttackStruct) error {
+func (p *Processor) Process(roomId Room.Id, uses, drops []ItemActionStruct, abilities []AbilityStruct, attacks []AttackStruct, raids []RaidStruct, moves []MoveStruct, bonusAttacks []BonusAttackStruct) error {
 	defer func() {
 		p.dl.Reset(roomId)
 	}()
 
+	// A new turn brings every ability on cooldown a turn closer.
+	p.cd.Tick(roomId)
+
 	changes := UpdateMessage.New()
 
 	fm, found := p.r[roomId]
@@ -75,9 +97,25 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 	// Track which dead players we've already processed so we don't double-drop / double-respawn.
 	processedDead := make(map[Player.Id]struct{})
 
+	// The players waiting to respawn stay dead the whole turn, and the ones
+	// who just respawned cannot die during it.
+	invulnerable := make(map[Player.Id]struct{})
+	for id, pl := range fm.Players {
+		if pl.IsRespawning() {
+			p.dl.PronounceDead(roomId, id)
+			processedDead[id] = struct{}{}
+		}
+		if pl.Status.Invulnerable {
+			invulnerable[id] = struct{}{}
+			pl.Status.Invulnerable = false
+		}
+	}
+	fallen := make(map[Player.Id]struct{})
+
 	// Resolve newly-dead players:
 	// - drop item where they died
-	// - move to spawn immediately (removes them from old tile)
+	// - move to spawn immediately (removes them from old tile), or off the
+	//   map until their respawn timer runs out
 	resolveNewDeaths := func() error {
 		for _, deadId := range p.dl.GetListOfDead(roomId) {
 			if _, done := processedDead[deadId]; done {
@@ -89,30 +127,157 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 				return errors.New("player not found")
 			}
 
+			// A healed player shrugs the death off.
+			if player.Status.BonusHP > 0 {
+				player.Status.BonusHP--
+				p.dl.Revive(roomId, deadId)
+				continue
+			}
+			if _, found := invulnerable[deadId]; found {
+				p.dl.Revive(roomId, deadId)
+				continue
+			}
+
 			deathX, deathY := player.X, player.Y
 
-			if err := p.dropBagOnTile(fm, changes, player, deathX, deathY); err != nil {
+			lost := player.Inventory
+			if fm.Settings.DeathDrop == Room.DropOne && len(lost) > 1 {
+				lost = lost[:1]
+			}
+			if err := p.dropItems(fm, changes, player, deathX, deathY, slices.Clone(lost)); err != nil {
 				return err
 			}
 
-			// 2) Move dead player to spawn immediately
-			spawnX, spawnY := fm.Map.GetSpawn(Team.Enum(player.TeamNumber))
+			fm.Penalties[player.TeamNumber] += fm.Settings.DeathPenalty
 
-			changes.UpsertPlayer(deadId, spawnX, spawnY, deathX, deathY, nil)
+			processedDead[deadId] = struct{}{}
 
-			if err := p.movePlayerOnMap(fm, player, spawnX, spawnY, deathX, deathY); err != nil {
-				return err
+			if fm.Settings.RespawnTurns > 0 {
+				tile, err := fm.Map.GetTile(deathX, deathY)
+				if err != nil {
+					return err
+				}
+				tile.RemovePlayer(deadId)
+
+				player.X, player.Y = Player.OffBoard, Player.OffBoard
+				player.Status.RespawnTurns = fm.Settings.RespawnTurns
+				fallen[deadId] = struct{}{}
+
+				changes.UpsertPlayer(deadId, player.X, player.Y, deathX, deathY, player.InventoryIds())
+				continue
 			}
 
-			processedDead[deadId] = struct{}{}
+			// 2) Move dead player to spawn immediately
+			if err := p.respawn(fm, changes, player); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
 
+	// -------------------
+	// Phase: Item
+	// -------------------
+	if err := p.hub.PublishToAll(string(roomId), Event.PhaseStruct{Phase: Event.ItemPhase}); err != nil {
+		return err
+	}
+
+	for _, use := range uses {
+		if p.dl.CheckIfDead(roomId, use.Id) {
+			continue
+		}
+
+		pl, found := fm.Players[use.Id]
+		if !found {
+			return errors.New("player not found")
+		}
+
+		item := pl.Find(use.ItemId)
+		if item == nil || !item.IsConsumable() {
+			continue
+		}
+
+		pl.Take(item.Id)
+		pl.Consume(item)
+		changes.UpsertPlayer(pl.Id, pl.X, pl.Y, pl.X, pl.Y, pl.InventoryIds())
+		changes.AddEffect(pl.Id, item.Effect)
+	}
+
+	// dropped keeps the players from picking up again what they dropped this turn.
+	dropped := make(map[Item.Id]Player.Id)
+	for _, drop := range drops {
+		if p.dl.CheckIfDead(roomId, drop.Id) {
+			continue
+		}
+
+		pl, found := fm.Players[drop.Id]
+		if !found {
+			return errors.New("player not found")
+		}
+
+		item := pl.Find(drop.ItemId)
+		if item == nil {
+			continue
+		}
+
+		if err := p.dropItems(fm, changes, pl, pl.X, pl.Y, []*Item.Struct{item}); err != nil {
+			return err
+		}
+		dropped[item.Id] = pl.Id
+	}
+
+	// -------------------
+	// Phase: Ability
+	// -------------------
+	if err := p.hub.PublishToAll(string(roomId), Event.PhaseStruct{Phase: Event.AbilityPhase}); err != nil {
+		return err
+	}
+
+	// shields maps the protected players to the Fighter protecting them.
+	shields := make(map[Player.Id]Player.Id)
+	protector := func(defenderId Player.Id) Player.Id {
+		if shieldId, found := shields[defenderId]; found && !p.dl.CheckIfDead(roomId, shieldId) {
+			return shieldId
+		}
+		return defenderId
+	}
+
+	for _, ability := range abilities {
+		if p.dl.CheckIfDead(roomId, ability.Id) {
+			continue
+		}
+
+		user, found := fm.Players[ability.Id]
+		if !found {
+			return errors.New("player not found")
+		}
+
+		definition, found := a.Get(ability.Ability)
+		if !found || !user.Class.HasAbility(ability.Ability) || p.cd.Remaining(roomId, user.Id, ability.Ability) > 0 {
+			continue
+		}
+
+		used, err := p.useAbility(roomId, fm, changes, user, ability, shields, protector)
+		if err != nil {
+			return err
+		}
+		if used {
+			p.cd.Start(roomId, user.Id, ability.Ability, definition.Cooldown)
+		}
+	}
+
+	if err := p.pb.WaitUntilAllAreUnblocked(roomId); err != nil {
+		return err
+	}
+
+	if err := resolveNewDeaths(); err != nil {
+		return err
+	}
+
 	// -------------------
 	// Phase: Attack
 	// -------------------
-	if err := p.hub.PublishToAll(string(roomId), "Phase", "Attack"); err != nil {
+	if err := p.hub.PublishToAll(string(roomId), Event.PhaseStruct{Phase: Event.AttackPhase}); err != nil {
 		return err
 	}
 
@@ -125,25 +290,17 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 			continue
 		}
 
-		if defenderId == "treasure" {
-			attacker, found := fm.Players[attackerId]
-			if !found {
-				return errors.New("attacker does not exist")
-			}
-			if _, err := fm.Map.DisperseItems(Team.Enum(attacker.TeamNumber)); err != nil {
-				return err
-			} else {
-				// TODO: disperse items
-			}
+		// Dead cannot receive attacks, nor can the players who just respawned
+		if p.dl.CheckIfDead(roomId, defenderId) {
 			continue
 		}
-
-		// Dead cannot receive attacks
-		if p.dl.CheckIfDead(roomId, defenderId) {
+		if _, found := invulnerable[defenderId]; found {
 			continue
 		}
 
-		if _, err := p.startFight(roomId, attackerId, defenderId); err != nil {
+		defenderId = protector(defenderId)
+
+		if _, err := p.startFight(roomId, attackerId, defenderId, nil); err != nil {
 			return err
 		}
 	}
@@ -157,54 +314,153 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 		return err
 	}
 
+	// Raids follow the attacks, so that a raider killed by one does not raid.
+	for _, raid := range raids {
+		if p.dl.CheckIfDead(roomId, raid.Id) {
+			continue
+		}
+
+		raider, found := fm.Players[raid.Id]
+		if !found {
+			return errors.New("player not found")
+		}
+
+		team := Team.Enum(raid.Team)
+		if int(team) == raider.TeamNumber || !slices.Contains(fm.Map.Teams(), team) {
+			continue
+		}
+		chestX, chestY := fm.Map.GetTeamTreasureChestLocation(team)
+		if distance(Movement.Position{X: raider.X, Y: raider.Y}, Movement.Position{X: chestX, Y: chestY}) > raider.Class.Range {
+			continue
+		}
+
+		if defenderId, found := p.chestDefender(roomId, fm, team); found && fm.Settings.ChestDefense {
+			defenderId = protector(defenderId)
+
+			if _, err := p.startFight(roomId, raider.Id, defenderId, nil); err != nil {
+				return err
+			}
+			if err := p.pb.WaitUntilUnblocked(roomId, raider.Id); err != nil {
+				return err
+			}
+			if err := p.pb.WaitUntilUnblocked(roomId, defenderId); err != nil {
+				return err
+			}
+			if err := resolveNewDeaths(); err != nil {
+				return err
+			}
+
+			if p.dl.CheckIfDead(roomId, raider.Id) {
+				continue
+			}
+		}
+
+		if err := p.raidChest(roomId, fm, changes, raider, team); err != nil {
+			return err
+		}
+	}
+
 	// -------------------
 	// Phase: Move
 	// -------------------
-	if err := p.hub.PublishToAll(string(roomId), "Phase", "Move"); err != nil {
+	if err := p.hub.PublishToAll(string(roomId), Event.PhaseStruct{Phase: Event.MovePhase}); err != nil {
 		return err
 	}
 
+	// The map is authoritative: a route that does not hold on it is dropped
+	// and the player stays put.
+	routes := make(map[Player.Id][]Movement.Position)
 	for _, move := range moves {
-		playerId := move.Id
-
 		// Dead should not move
-		if p.dl.CheckIfDead(roomId, playerId) {
+		if p.dl.CheckIfDead(roomId, move.Id) {
 			continue
 		}
 
-		pl, ok := fm.Players[playerId]
+		pl, ok := fm.Players[move.Id]
 		if !ok {
 			return errors.New("player not found")
 		}
 
-		changes.UpsertPlayer(playerId, move.X, move.Y, move.PrevX, move.PrevY, nil)
+		route := move.Route()
+		if move.PrevX != pl.X || move.PrevY != pl.Y {
+			continue
+		}
+		if err := Movement.Validate(fm.Map, Movement.Position{X: pl.X, Y: pl.Y}, route, pl.Speed()); err != nil {
+			continue
+		}
+		routes[move.Id] = route
+		// A speed boost lasts for a single move.
+		pl.Status.SpeedBonus = 0
+	}
 
-		if err := p.movePlayerOnMap(fm, pl, move.X, move.Y, move.PrevX, move.PrevY); err != nil {
+	alive := make(map[Player.Id]*Player.Struct, len(fm.Players))
+	for id, pl := range fm.Players {
+		if !p.dl.CheckIfDead(roomId, id) {
+			alive[id] = pl
+		}
+	}
+	interrupted := ApplyZoneOfControl(routes, alive)
+
+	// Enemies swapping tiles or crossing each other fight before anyone moves.
+	// The loser respawns and does not move.
+	for _, conflict := range FindConflicts(routes, alive) {
+		if p.dl.CheckIfDead(roomId, conflict.First) || p.dl.CheckIfDead(roomId, conflict.Second) {
+			continue
+		}
+
+		if _, err := p.startFight(roomId, conflict.First, conflict.Second, &m.Point{X: conflict.X, Y: conflict.Y}); err != nil {
+			return err
+		}
+
+		if err := p.pb.WaitUntilUnblocked(roomId, conflict.First); err != nil {
+			return err
+		}
+		if err := p.pb.WaitUntilUnblocked(roomId, conflict.Second); err != nil {
+			return err
+		}
+
+		if err := resolveNewDeaths(); err != nil {
 			return err
 		}
+	}
 
-		// ✅ NEW: Deposit item if player is on their team's treasure chest
-		if pl.Bag != nil {
-			tile, err := fm.Map.GetTile(pl.X, pl.Y)
-			if err != nil {
+	// Everyone advances one tile per step, interacting with each tile entered.
+	for step := 0; ; step++ {
+		moved := false
+		for _, move := range moves {
+			route := routes[move.Id]
+			if step >= len(route) || p.dl.CheckIfDead(roomId, move.Id) {
+				continue
+			}
+			moved = true
+
+			pl := fm.Players[move.Id]
+			prevX, prevY := pl.X, pl.Y
+			changes.UpsertPlayer(pl.Id, route[step].X, route[step].Y, prevX, prevY, pl.InventoryIds())
+
+			if err := p.movePlayerOnMap(fm, pl, route[step].X, route[step].Y, prevX, prevY); err != nil {
 				return err
 			}
 
-			if tile.Flag == TileFlag.TREASURE_CHEST {
-				// ⚠️ Assumption: tile.TeamNumber exists and matches player.TeamNumber
-				if int(tile.Team) == pl.TeamNumber {
-					if err := p.dropBagOnTile(fm, changes, pl, pl.X, pl.Y); err != nil {
-						return err
-					}
-				}
+			if err := p.enterTile(fm, changes, pl, dropped); err != nil {
+				return err
 			}
 		}
+		if !moved {
+			break
+		}
+	}
+
+	for id, by := range interrupted {
+		if !p.dl.CheckIfDead(roomId, id) {
+			changes.InterruptPlayer(id, by)
+		}
 	}
 
 	// -------------------
 	// Phase: BonusAttack
 	// -------------------
-	if err := p.hub.PublishToAll(string(roomId), "Phase", "BonusAttack"); err != nil {
+	if err := p.hub.PublishToAll(string(roomId), Event.PhaseStruct{Phase: Event.BonusAttackPhase}); err != nil {
 		return err
 	}
 
@@ -235,6 +491,9 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 			if p.dl.CheckIfDead(roomId, pl.Id) {
 				continue
 			}
+			if _, found := invulnerable[pl.Id]; found {
+				continue
+			}
 			defenderId = pl.Id
 			break
 		}
@@ -242,7 +501,7 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 			continue
 		}
 
-		if _, err := p.startFight(roomId, attackerId, defenderId); err != nil {
+		if _, err := p.startFight(roomId, attackerId, protector(defenderId), nil); err != nil {
 			return err
 		}
 	}
@@ -259,7 +518,7 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 	// -------------------
 	// Phase: CollisionResolution
 	// -------------------
-	if err := p.hub.PublishToAll(string(roomId), "Phase", "CollisionResolution"); err != nil {
+	if err := p.hub.PublishToAll(string(roomId), Event.PhaseStruct{Phase: Event.CollisionResolutionPhase}); err != nil {
 		return err
 	}
 
@@ -275,132 +534,13 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 			continue
 		}
 
-		if tile.Player[0].TeamNumber != int(tile.Team) {
-			if items, err := fm.Map.DisperseItems(Team.Enum(tile.Team)); err != nil {
-				return err
-			} else {
-				for _, item := range items {
-					x, y := fm.Map.GetSpawn(Team.Enum(tile.Player[0].TeamNumber))
-					tile.Player[0].X = x
-					tile.Player[0].Y = y
-					changes.UpsertPlayer(tile.Player[0].Id, x, y, tile.Player[0].X, tile.Player[0].Y, nil)
-					changes.UpsertItem(item.Id, item.X, item.Y, tile.X, tile.Y)
-				}
-			}
-		}
-
 		if len(tile.Player) < 2 {
 			continue
 		}
 
-		var t0 []Player.Id
-		var t1 []Player.Id
-
-		for _, pl := range tile.Player {
-			if p.dl.CheckIfDead(roomId, pl.Id) {
-				continue
-			}
-			if pl.TeamNumber == 0 {
-				t0 = append(t0, pl.Id)
-			} else {
-				t1 = append(t1, pl.Id)
-			}
-		}
-
-		if len(t0) == 0 || len(t1) == 0 {
-			continue
-		}
-
-		removeAtSwap := func(list []Player.Id, idx int) []Player.Id {
-			last := len(list) - 1
-			list[idx] = list[last]
-			return list[:last]
-		}
-
-		side, err := randIndex(2)
-		if err != nil {
-			return err
-		}
-
-		var champ Player.Id
-		champTeam := side
-
-		if champTeam == 0 {
-			idx, err := randIndex(len(t0))
-			if err != nil {
-				return err
-			}
-			champ = t0[idx]
-			t0 = removeAtSwap(t0, idx)
-		} else {
-			idx, err := randIndex(len(t1))
-			if err != nil {
-				return err
-			}
-			champ = t1[idx]
-			t1 = removeAtSwap(t1, idx)
-		}
-
-		for len(t0) > 0 && len(t1) > 0 {
-			if p.dl.CheckIfDead(roomId, champ) {
-				// champ already resolved + moved to spawn, replace
-				if champTeam == 0 {
-					if len(t0) == 0 {
-						break
-					}
-					idx, err := randIndex(len(t0))
-					if err != nil {
-						return err
-					}
-					champ = t0[idx]
-					t0 = removeAtSwap(t0, idx)
-				} else {
-					if len(t1) == 0 {
-						break
-					}
-					idx, err := randIndex(len(t1))
-					if err != nil {
-						return err
-					}
-					champ = t1[idx]
-					t1 = removeAtSwap(t1, idx)
-				}
-				continue
-			}
-
-			var challenger Player.Id
-			var challengerIdx int
-
-			if champTeam == 0 {
-				challengerIdx, err = randIndex(len(t1))
-				if err != nil {
-					return err
-				}
-				challenger = t1[challengerIdx]
-			} else {
-				challengerIdx, err = randIndex(len(t0))
-				if err != nil {
-					return err
-				}
-				challenger = t0[challengerIdx]
-			}
-
-			if p.dl.CheckIfDead(roomId, challenger) {
-				// should not happen often if resolveNewDeaths ran, but be safe
-				if champTeam == 0 {
-					t1 = removeAtSwap(t1, challengerIdx)
-				} else {
-					t0 = removeAtSwap(t0, challengerIdx)
-				}
-				continue
-			}
-
-			fight, err := p.startFight(roomId, champ, challenger)
-			if err != nil {
-				return err
-			}
-
-			if err := p.broadcastFight(roomId, fight); err != nil {
+		bracket := NewBracket(tile.Player, func(id Player.Id) bool { return !p.dl.CheckIfDead(roomId, id) }, randIndex)
+		err := bracket.Run(func(champ, challenger Player.Id) error {
+			if _, err := p.startFight(roomId, champ, challenger, &m.Point{X: tile.X, Y: tile.Y}); err != nil {
 				return err
 			}
 
@@ -412,38 +552,10 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 			}
 
 			// ✅ Immediately process deaths from this collision fight
-			if err := resolveNewDeaths(); err != nil {
-				return err
-			}
-
-			champDead := p.dl.CheckIfDead(roomId, champ)
-			challDead := p.dl.CheckIfDead(roomId, challenger)
-
-			if !champDead && !challDead {
-				challDead = true
-			}
-
-			if challDead {
-				if champTeam == 0 {
-					t1 = removeAtSwap(t1, challengerIdx)
-				} else {
-					t0 = removeAtSwap(t0, challengerIdx)
-				}
-				continue
-			}
-
-			if champDead {
-				// challenger becomes champ
-				champ = challenger
-				if champTeam == 0 {
-					t1 = removeAtSwap(t1, challengerIdx)
-					champTeam = 1
-				} else {
-					t0 = removeAtSwap(t0, challengerIdx)
-					champTeam = 0
-				}
-				continue
-			}
+			return resolveNewDeaths()
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -456,6 +568,35 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 		return err
 	}
 
+	// An enemy still standing on a treasure chest once the fights are over raids it.
+	for _, team := range fm.Map.Teams() {
+		chestX, chestY := fm.Map.GetTeamTreasureChestLocation(team)
+		tile, err := fm.Map.GetTile(chestX, chestY)
+		if err != nil {
+			return err
+		}
+		if len(tile.Items) == 0 {
+			continue
+		}
+
+		var raider *Player.Struct
+		for _, pl := range tile.Player {
+			if pl.TeamNumber == int(team) || p.dl.CheckIfDead(roomId, pl.Id) {
+				continue
+			}
+			if raider == nil || pl.Id < raider.Id {
+				raider = pl
+			}
+		}
+		if raider == nil {
+			continue
+		}
+
+		if err := p.raidChest(roomId, fm, changes, raider, team); err != nil {
+			return err
+		}
+	}
+
 	// -------------------
 	// Item pickup (alive only)
 	// -------------------
@@ -478,44 +619,63 @@ func (p *Processor) Process(roomId Room.Id, attacks []AttackStruct, moves []Move
 			if p.dl.CheckIfDead(roomId, pl.Id) {
 				continue
 			}
-			if pl.Bag != nil {
-				continue
-			}
-			if len(tile.Items) == 0 {
-				break
-			}
-
-			it := tile.Items[0]
-			tile.Items = tile.Items[1:]
-
-			prevX, prevY := it.X, it.Y
-			it.X, it.Y = -1, -1
-			changes.UpsertItem(it.Id, -1, -1, prevX, prevY)
+			p.pickUp(changes, pl, tile, dropped)
+		}
+	}
 
-			pl.Bag = it
+	// The players who sat out long enough respawn in time to plan the next turn.
+	for _, pl := range fm.Players {
+		if _, found := fallen[pl.Id]; found || !pl.IsRespawning() {
+			continue
+		}
 
-			itemId := it.Id
-			changes.UpsertPlayer(pl.Id, pl.X, pl.Y, pl.X, pl.Y, &itemId)
+		pl.Status.RespawnTurns--
+		if pl.IsRespawning() {
+			continue
+		}
+		if err := p.respawn(fm, changes, pl); err != nil {
+			return err
 		}
 	}
 
-	env := UpdateEnvelope{
-		MessageType: "Update",
-		Message:     changes,
+	teams := fm.Map.Teams()
+	changes.Scores = make(map[int]int, len(teams))
+	for _, team := range teams {
+		changes.Scores[int(team)] = fm.Score(int(team))
 	}
 
-	payload, err := json.Marshal(env)
-	if err != nil {
-		return err
+	// The effects lasting a number of turns wear off once the turn is seen.
+	defer func() {
+		for _, pl := range fm.Players {
+			pl.EndTurn()
+		}
+	}()
+
+	if fm.Settings.FogOfWar {
+		p.publishFilteredUpdate(roomId, fm, changes)
+		return nil
 	}
 
-	if err := p.hub.PublishToAll(string(roomId), "Update", string(payload)); err != nil {
+	if err := p.hub.PublishToAll(string(roomId), Event.UpdateStruct{Struct: changes}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// publishFilteredUpdate sends every player the part of the update they can
+// see, while the admin and the spectators get all of it. Subscribers that are
+// not connected simply miss the update.
+func (p *Processor) publishFilteredUpdate(roomId Room.Id, fm *Room.Room, changes *UpdateMessage.Struct) {
+	for playerId := range fm.Players {
+		filtered := Visibility.FilterUpdate(playerId, fm.Players, fm.Items, changes)
+		_ = p.hub.Publish(string(roomId), string(playerId), Event.UpdateStruct{Struct: filtered})
+	}
+
+	_ = p.hub.Publish(string(roomId), "admin", Event.UpdateStruct{Struct: changes})
+	_ = p.hub.PublishToSpectators(string(roomId), Event.UpdateStruct{Struct: changes})
+}
+
 func getRandomGame() (Game.Type, error) {
 	nBig, err := crand.Int(crand.Reader, big.NewInt(int64(len(Game.List))))
 	if err != nil {
@@ -524,7 +684,10 @@ func getRandomGame() (Game.Type, error) {
 	return Game.List[int(nBig.Int64())], nil
 }
 
-func (p *Processor) startFight(roomId Room.Id, attackerId, defenderId Player.Id) (*Fight.Struct, error) {
+// startFight blocks the fighters until they settle the fight and announces it
+// once to every subscriber concerned: the witnesses of the scene when the
+// fight breaks out in the open, the fighters and the spectators otherwise.
+func (p *Processor) startFight(roomId Room.Id, attackerId, defenderId Player.Id, scene *m.Point) (*Fight.Struct, error) {
 	if err := p.pb.WaitUntilUnblocked(roomId, attackerId); err != nil {
 		return nil, err
 	}
@@ -550,34 +713,32 @@ func (p *Processor) startFight(roomId Room.Id, attackerId, defenderId Player.Id)
 		return nil, err
 	}
 
-	msg, err := json.Marshal(fight)
-	if err != nil {
-		_ = p.pb.Unblock(roomId, attackerId)
-		_ = p.pb.Unblock(roomId, defenderId)
-		return nil, err
+	msg := Event.FightStruct{Struct: fight}
+
+	if scene != nil {
+		if err := p.publishWitnessed(roomId, scene.X, scene.Y, msg, attackerId, defenderId); err != nil {
+			_ = p.pb.Unblock(roomId, attackerId)
+			_ = p.pb.Unblock(roomId, defenderId)
+			return nil, err
+		}
+		return fight, nil
 	}
 
-	if err := p.hub.Publish(string(roomId), string(attackerId), "Fight", string(msg)); err != nil {
+	if err := p.hub.Publish(string(roomId), string(attackerId), msg); err != nil {
 		_ = p.pb.Unblock(roomId, attackerId)
 		_ = p.pb.Unblock(roomId, defenderId)
 		return nil, err
 	}
-	if err := p.hub.Publish(string(roomId), string(defenderId), "Fight", string(msg)); err != nil {
+	if err := p.hub.Publish(string(roomId), string(defenderId), msg); err != nil {
 		_ = p.pb.Unblock(roomId, attackerId)
 		_ = p.pb.Unblock(roomId, defenderId)
 		return nil, err
 	}
 
-	return fight, nil
-}
-
-func (p *Processor) broadcastFight(roomId Room.Id, fight *Fight.Struct) error {
-	msg, err := json.Marshal(fight)
-	if err != nil {
-		return err
-	}
+	// Spectators are best effort, the fight goes on without them.
+	_ = p.hub.PublishToSpectators(string(roomId), msg)
 
-	return p.hub.PublishToAll(string(roomId), "Fight", string(msg))
+	return fight, nil
 }
 
 func randIndex(n int) (int, error) {
@@ -629,34 +790,237 @@ func (p *Processor) movePlayerOnMap(fm *Room.Room, player *Player.Struct, newX,
 	return nil
 }
 
-func (p *Processor) dropBagOnTile(fm *Room.Room, changes *UpdateMessage.Struct, player *Player.Struct, tileX, tileY int) error {
-	if player.Bag == nil {
+// respawn puts the player back on the spawn of their team, from where they
+// died or from off the map.
+func (p *Processor) respawn(fm *Room.Room, changes *UpdateMessage.Struct, player *Player.Struct) error {
+	spawnX, spawnY, err := fm.Map.GetSpawn(Team.Enum(player.TeamNumber))
+	if err != nil {
+		return err
+	}
+	prevX, prevY := player.X, player.Y
+
+	changes.UpsertPlayer(player.Id, spawnX, spawnY, prevX, prevY, player.InventoryIds())
+	player.Status.Invulnerable = fm.Settings.SpawnInvulnerability
+
+	if prevX == Player.OffBoard {
+		tile, err := fm.Map.GetTile(spawnX, spawnY)
+		if err != nil {
+			return err
+		}
+		tile.AddPlayer(player)
+		player.X, player.Y = spawnX, spawnY
 		return nil
 	}
 
-	tile, err := fm.Map.GetTile(tileX, tileY)
+	return p.movePlayerOnMap(fm, player, spawnX, spawnY, prevX, prevY)
+}
+
+// useAbility resolves an ability and reports whether it took effect. Shields
+// and steals work on adjacent players, volleys on a tile within range.
+func (p *Processor) useAbility(roomId Room.Id, fm *Room.Room, changes *UpdateMessage.Struct, user *Player.Struct, ability AbilityStruct, shields map[Player.Id]Player.Id, protector func(Player.Id) Player.Id) (bool, error) {
+	userAt := Movement.Position{X: user.X, Y: user.Y}
+
+	switch ability.Ability {
+	case a.Shield:
+		ally, found := fm.Players[ability.TargetId]
+		if !found || ally.Id == user.Id || ally.TeamNumber != user.TeamNumber || p.dl.CheckIfDead(roomId, ally.Id) {
+			return false, nil
+		}
+		if distance(userAt, Movement.Position{X: ally.X, Y: ally.Y}) > 1 {
+			return false, nil
+		}
+		shields[ally.Id] = user.Id
+		return true, nil
+
+	case a.Steal:
+		enemy, found := fm.Players[ability.TargetId]
+		if !found || enemy.TeamNumber == user.TeamNumber || p.dl.CheckIfDead(roomId, enemy.Id) {
+			return false, nil
+		}
+		if distance(userAt, Movement.Position{X: enemy.X, Y: enemy.Y}) > 1 {
+			return false, nil
+		}
+		if protector(enemy.Id) != enemy.Id || len(enemy.Inventory) == 0 || user.IsFull() {
+			return false, nil
+		}
+		user.Carry(enemy.Take(enemy.Inventory[0].Id))
+		changes.UpsertPlayer(enemy.Id, enemy.X, enemy.Y, enemy.X, enemy.Y, enemy.InventoryIds())
+		changes.UpsertPlayer(user.Id, user.X, user.Y, user.X, user.Y, user.InventoryIds())
+		return true, nil
+
+	case a.Volley:
+		if distance(userAt, Movement.Position{X: ability.X, Y: ability.Y}) > user.Class.Range {
+			return false, nil
+		}
+		tile, err := fm.Map.GetTile(ability.X, ability.Y)
+		if err != nil {
+			return false, nil
+		}
+
+		var defenders []Player.Id
+		for _, pl := range tile.Player {
+			if pl.TeamNumber != user.TeamNumber && !p.dl.CheckIfDead(roomId, pl.Id) {
+				defenders = append(defenders, pl.Id)
+			}
+		}
+		slices.Sort(defenders)
+
+		for _, defenderId := range defenders {
+			if _, err := p.startFight(roomId, user.Id, protector(defenderId), nil); err != nil {
+				return false, err
+			}
+		}
+		return len(defenders) > 0, nil
+	}
+
+	return false, nil
+}
+
+// chestDefender returns the alive player of the team standing closest to
+// their treasure chest, at most a tile away, the lowest id first.
+func (p *Processor) chestDefender(roomId Room.Id, fm *Room.Room, team Team.Enum) (Player.Id, bool) {
+	chestX, chestY := fm.Map.GetTeamTreasureChestLocation(team)
+	chest := Movement.Position{X: chestX, Y: chestY}
+
+	var defender *Player.Struct
+	for _, pl := range fm.Players {
+		if pl.TeamNumber != int(team) || p.dl.CheckIfDead(roomId, pl.Id) {
+			continue
+		}
+		d := distance(chest, Movement.Position{X: pl.X, Y: pl.Y})
+		if d > 1 {
+			continue
+		}
+		if defender == nil {
+			defender = pl
+			continue
+		}
+		best := distance(chest, Movement.Position{X: defender.X, Y: defender.Y})
+		if d < best || (d == best && pl.Id < defender.Id) {
+			defender = pl
+		}
+	}
+
+	if defender == nil {
+		return "", false
+	}
+	return defender.Id, true
+}
+
+// raidChest scatters the items of the treasure chest of the team over the
+// map, reports them in the update and tells the witnesses about the raid.
+// Raiding an empty chest goes unnoticed.
+func (p *Processor) raidChest(roomId Room.Id, fm *Room.Room, changes *UpdateMessage.Struct, raider *Player.Struct, team Team.Enum) error {
+	chestX, chestY := fm.Map.GetTeamTreasureChestLocation(team)
+
+	items, err := fm.Map.DisperseItems(team)
 	if err != nil {
 		return err
 	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	scattered := make([]Item.Id, 0, len(items))
+	for _, item := range items {
+		fm.Items[item.Id] = item
+		changes.UpsertItem(item.Id, item.X, item.Y, chestX, chestY)
+		scattered = append(scattered, item.Id)
+	}
 
-	item := player.Bag
-	prevX, prevY := item.X, item.Y
+	return p.publishWitnessed(roomId, chestX, chestY, Event.ChestRaidedStruct{RaiderId: raider.Id, Team: int(team), Items: scattered}, raider.Id)
+}
 
-	// Put item on the board where the player is standing
-	item.X, item.Y = tileX, tileY
-	tile.AddItem(item)
+// publishWitnessed tells everyone what happened on the tile at x, y. Under fog
+// of war, only the players involved and the ones seeing the tile learn of it,
+// along with the admin and the spectators.
+func (p *Processor) publishWitnessed(roomId Room.Id, x, y int, event Event.Interface, involved ...Player.Id) error {
+	fm, found := p.r[roomId]
+	if !found || !fm.Settings.FogOfWar {
+		return p.hub.PublishToAll(string(roomId), event)
+	}
 
-	// Clear bag
-	player.Bag = nil
+	for _, id := range Visibility.Witnesses(fm.Players, x, y, involved...) {
+		_ = p.hub.Publish(string(roomId), string(id), event)
+	}
+	_ = p.hub.Publish(string(roomId), "admin", event)
+	_ = p.hub.PublishToSpectators(string(roomId), event)
+	return nil
+}
+
+// enterTile deposits the treasures of the player on their team's treasure
+// chest, or picks up an item lying on any other tile.
+func (p *Processor) enterTile(fm *Room.Room, changes *UpdateMessage.Struct, player *Player.Struct, dropped map[Item.Id]Player.Id) error {
+	tile, err := fm.Map.GetTile(player.X, player.Y)
+	if err != nil {
+		return err
+	}
+
+	if tile.Flag == TileFlag.TREASURE_CHEST {
+		if int(tile.Team) == player.TeamNumber {
+			return p.dropItems(fm, changes, player, player.X, player.Y, player.TakeTreasures())
+		}
+		return nil
+	}
 
-	// Broadcast updates
-	changes.UpsertPlayer(player.Id, player.X, player.Y, player.X, player.Y, nil)
-	changes.UpsertItem(item.Id, tileX, tileY, prevX, prevY)
+	p.pickUp(changes, player, tile, dropped)
 
 	return nil
 }
 
-type UpdateEnvelope struct {
-	MessageType string      `json:"MessageType"`
-	Message     interface{} `json:"Message"`
+// pickUp takes the first item of the tile the player has room for, leaving
+// what they dropped this turn. A consumable found with a full inventory is
+// consumed on the spot.
+func (p *Processor) pickUp(changes *UpdateMessage.Struct, player *Player.Struct, tile *m.Tile, dropped map[Item.Id]Player.Id) {
+	index := slices.IndexFunc(tile.Items, func(item *Item.Struct) bool {
+		if dropper, found := dropped[item.Id]; found && dropper == player.Id {
+			return false
+		}
+		return item.IsConsumable() || !player.IsFull()
+	})
+	if index < 0 {
+		return
+	}
+
+	item := tile.Items[index]
+	tile.Items = slices.Delete(tile.Items, index, index+1)
+
+	changes.UpsertItem(item.Id, -1, -1, item.X, item.Y)
+	item.X, item.Y = -1, -1
+
+	if player.IsFull() {
+		player.Consume(item)
+		changes.UpsertPlayer(player.Id, player.X, player.Y, player.X, player.Y, player.InventoryIds())
+		changes.AddEffect(player.Id, item.Effect)
+		return
+	}
+
+	player.Carry(item)
+	changes.UpsertPlayer(player.Id, player.X, player.Y, player.X, player.Y, player.InventoryIds())
+}
+
+// dropItems puts the items of the player on the tile, taking them out of
+// their inventory if need be.
+func (p *Processor) dropItems(fm *Room.Room, changes *UpdateMessage.Struct, player *Player.Struct, tileX, tileY int, items []*Item.Struct) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tile, err := fm.Map.GetTile(tileX, tileY)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		player.Take(item.Id)
+
+		prevX, prevY := item.X, item.Y
+		item.X, item.Y = tileX, tileY
+		tile.AddItem(item)
+
+		changes.UpsertItem(item.Id, tileX, tileY, prevX, prevY)
+	}
+	changes.UpsertPlayer(player.Id, player.X, player.Y, player.X, player.Y, player.InventoryIds())
+
+	return nil
 }
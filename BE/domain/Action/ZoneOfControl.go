@@ -0,0 +1,71 @@
+package Action
+
+import (
+	"ChoHanJi/domain/Movement"
+	"ChoHanJi/domain/Player"
+	"maps"
+	"slices"
+)
+
+// ApplyZoneOfControl cuts the routes of the players entering a tile next to,
+// or onto, an enemy exerting a zone of control. The routes are walked one
+// step at a time by everyone at once, so the positions of the enemies are
+// those of the same step. It returns, for each stopped player, the enemy who
+// stopped them. Only the given players exert a zone of control, the dead
+// ones should be left out.
+func ApplyZoneOfControl(routes map[Player.Id][]Movement.Position, players map[Player.Id]*Player.Struct) map[Player.Id]Player.Id {
+	ids := slices.Sorted(maps.Keys(routes))
+	playerIds := slices.Sorted(maps.Keys(players))
+
+	positions := make(map[Player.Id]Movement.Position, len(players))
+	longest := 0
+	for _, player := range players {
+		positions[player.Id] = Movement.Position{X: player.X, Y: player.Y}
+	}
+	for _, route := range routes {
+		longest = max(longest, len(route))
+	}
+
+	interrupted := make(map[Player.Id]Player.Id)
+	for step := range longest {
+		var entered []Player.Id
+		for _, id := range ids {
+			if step < len(routes[id]) {
+				positions[id] = routes[id][step]
+				entered = append(entered, id)
+			}
+		}
+
+		for _, id := range entered {
+			mover, found := players[id]
+			if !found || mover.Class.IgnoresZoneOfControl || step == len(routes[id])-1 {
+				continue
+			}
+
+			for _, enemyId := range playerIds {
+				enemy := players[enemyId]
+				if enemy.TeamNumber == mover.TeamNumber || !enemy.Class.ExertsZoneOfControl {
+					continue
+				}
+				if distance(positions[id], positions[enemyId]) <= 1 {
+					routes[id] = routes[id][:step+1]
+					interrupted[id] = enemyId
+					break
+				}
+			}
+		}
+	}
+
+	return interrupted
+}
+
+func distance(a, b Movement.Position) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -1,15 +1,59 @@
 package Class
 
+import (
+	"ChoHanJi/domain/Ability"
+	"slices"
+)
+
+// Action names a class may list in Actions. Skipping and withdrawing are
+// always allowed.
+const (
+	Move        = "Move"
+	Attack      = "Attack"
+	BonusAttack = "BonusAttack"
+	Raid        = "Raid"
+	UseAbility  = "Ability"
+	Drop        = "Drop"
+	Use         = "Use"
+)
+
+var knownActions = []string{Move, Attack, BonusAttack, Raid, UseAbility, Drop, Use}
+
 type Struct struct {
-	Power         int
-	Defence       int
-	Range         int
-	MovementSpeed int
-	InitialHP     int
+	Name          string   `json:"Name"`
+	Aliases       []string `json:"Aliases"`
+	Description   string   `json:"Description"`
+	Power         int      `json:"Power"`
+	Defence       int      `json:"Defence"`
+	Range         int      `json:"Range"`
+	MovementSpeed int      `json:"MovementSpeed"`
+	InitialHP     int      `json:"InitialHP"`
+	Vision        int      `json:"Vision"`
+	// Capacity is how many items the player carries at once.
+	Capacity int `json:"Capacity"`
+	// CarryWeight is the weight the player carries without slowing down.
+	CarryWeight int `json:"CarryWeight"`
+	// ExertsZoneOfControl stops the enemies entering a tile next to the player.
+	ExertsZoneOfControl bool `json:"ExertsZoneOfControl"`
+	// IgnoresZoneOfControl lets the player move past the enemies exerting one.
+	IgnoresZoneOfControl bool           `json:"IgnoresZoneOfControl"`
+	Abilities            []Ability.Enum `json:"Abilities"`
+	Actions              []string       `json:"Actions"`
 }
 
-var (
-	Fighter Struct = Struct{2, 1, 1, 1, 2} // can move and attack
-	Ranger  Struct = Struct{2, 0, 2, 1, 2} // can attack from afar
-	Rogue   Struct = Struct{2, 0, 1, 3, 2} // can move around fighter
-)
+// Allows reports whether the class may submit the action.
+func (s Struct) Allows(action string) bool {
+	return slices.Contains(s.Actions, action)
+}
+
+// HasAbility reports whether the class can use the ability.
+func (s Struct) HasAbility(ability Ability.Enum) bool {
+	return slices.Contains(s.Abilities, ability)
+}
+
+func (s Struct) clone() Struct {
+	s.Aliases = slices.Clone(s.Aliases)
+	s.Abilities = slices.Clone(s.Abilities)
+	s.Actions = slices.Clone(s.Actions)
+	return s
+}
@@ -0,0 +1,123 @@
+package Class
+
+import (
+	"ChoHanJi/domain/Ability"
+	"ChoHanJi/domain/Failure"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrNotFound = Failure.New(Failure.Invalid, "class not defined")
+	ErrInvalid  = errors.New("invalid class definition")
+)
+
+//go:embed classes.json
+var defaultClasses []byte
+
+// Registry holds the classes players can pick from, looked up by name or
+// alias regardless of case.
+type Registry struct {
+	classes []Struct
+	byName  map[string]int
+}
+
+// Default returns the registry of the classes shipped with the game.
+func Default() (*Registry, error) {
+	return Load(defaultClasses)
+}
+
+// LoadFile returns the registry of the classes defined in a JSON file.
+func LoadFile(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Class.LoadFile: %w", err)
+	}
+	return Load(data)
+}
+
+// Load decodes a JSON list of classes and validates it.
+func Load(data []byte) (*Registry, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var classes []Struct
+	if err := decoder.Decode(&classes); err != nil {
+		return nil, fmt.Errorf("Class.Load: %w: %v", ErrInvalid, err)
+	}
+	if len(classes) == 0 {
+		return nil, fmt.Errorf("Class.Load: %w: no class defined", ErrInvalid)
+	}
+
+	registry := &Registry{byName: make(map[string]int)}
+	for i, class := range classes {
+		if err := validate(class); err != nil {
+			return nil, fmt.Errorf("Class.Load: %w", err)
+		}
+
+		for _, name := range append([]string{class.Name}, class.Aliases...) {
+			key := strings.ToUpper(strings.TrimSpace(name))
+			if key == "" {
+				return nil, fmt.Errorf("Class.Load: %w: %s has an empty alias", ErrInvalid, class.Name)
+			}
+			if _, found := registry.byName[key]; found {
+				return nil, fmt.Errorf("Class.Load: %w: %q names two classes", ErrInvalid, name)
+			}
+			registry.byName[key] = i
+		}
+
+		registry.classes = append(registry.classes, class)
+	}
+
+	return registry, nil
+}
+
+func validate(class Struct) error {
+	if strings.TrimSpace(class.Name) == "" {
+		return fmt.Errorf("%w: a class has no name", ErrInvalid)
+	}
+
+	if class.Power < 0 || class.Defence < 0 || class.Range < 0 || class.Vision < 0 || class.CarryWeight < 0 {
+		return fmt.Errorf("%w: %s has a negative stat", ErrInvalid, class.Name)
+	}
+	if class.MovementSpeed < 1 || class.InitialHP < 1 || class.Capacity < 1 {
+		return fmt.Errorf("%w: %s needs a movement speed, initial HP and capacity of at least 1", ErrInvalid, class.Name)
+	}
+
+	for _, action := range class.Actions {
+		if !slices.Contains(knownActions, action) {
+			return fmt.Errorf("%w: %s allows the unknown action %q", ErrInvalid, class.Name, action)
+		}
+	}
+	for _, ability := range class.Abilities {
+		if _, found := Ability.Get(ability); !found {
+			return fmt.Errorf("%w: %s has the unknown ability %q", ErrInvalid, class.Name, ability)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the class with the given name or alias.
+func (r *Registry) Get(name string) (Struct, error) {
+	i, found := r.byName[strings.ToUpper(strings.TrimSpace(name))]
+	if !found {
+		return Struct{}, fmt.Errorf("Class.Get: %q %w", name, ErrNotFound)
+	}
+	return r.classes[i].clone(), nil
+}
+
+// List returns every class in definition order.
+func (r *Registry) List() []Struct {
+	classes := make([]Struct, 0, len(r.classes))
+	for _, class := range r.classes {
+		classes = append(classes, class.clone())
+	}
+	return classes
+}
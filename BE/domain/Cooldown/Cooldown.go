@@ -0,0 +1,69 @@
+package Cooldown
+
+import (
+	"ChoHanJi/domain/Ability"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	"sync"
+)
+
+// List tracks, across turns, how many turns each player still has to wait
+// before using each of their abilities again.
+type List struct {
+	lock  sync.Mutex
+	rooms map[Room.Id]map[Player.Id]map[Ability.Enum]int
+}
+
+func New() *List {
+	return &List{
+		rooms: make(map[Room.Id]map[Player.Id]map[Ability.Enum]int),
+	}
+}
+
+// Start puts the ability of the player on cooldown.
+func (l *List) Start(roomId Room.Id, playerId Player.Id, ability Ability.Enum, turns int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if turns <= 0 {
+		return
+	}
+
+	room, found := l.rooms[roomId]
+	if !found {
+		room = make(map[Player.Id]map[Ability.Enum]int)
+		l.rooms[roomId] = room
+	}
+
+	player, found := room[playerId]
+	if !found {
+		player = make(map[Ability.Enum]int)
+		room[playerId] = player
+	}
+
+	player[ability] = turns
+}
+
+// Remaining returns the number of turns before the player can use the ability.
+func (l *List) Remaining(roomId Room.Id, playerId Player.Id, ability Ability.Enum) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.rooms[roomId][playerId][ability]
+}
+
+// Tick counts a turn down for every ability on cooldown in the room.
+func (l *List) Tick(roomId Room.Id) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, player := range l.rooms[roomId] {
+		for ability, turns := range player {
+			if turns <= 1 {
+				delete(player, ability)
+			} else {
+				player[ability] = turns - 1
+			}
+		}
+	}
+}
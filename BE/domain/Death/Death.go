@@ -30,6 +30,16 @@ func (l *List) PronounceDead(roomId Room.Id, playerId Player.Id) {
 	room[playerId] = struct{}{}
 }
 
+// Revive takes the player off the list of the dead.
+func (l *List) Revive(roomId Room.Id, playerId Player.Id) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if room, found := l.list[roomId]; found {
+		delete(room, playerId)
+	}
+}
+
 func (l *List) CheckIfDead(roomId Room.Id, playerId Player.Id) bool {
 	l.lock.RLock()
 	defer l.lock.RUnlock()
@@ -0,0 +1,88 @@
+package Event
+
+//go:generate go run ../../cmd/eventschema -schema events.schema.json -ts ../../../FE/cho-han-ji/model/generated/Events.ts
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is bumped whenever a message contract changes. Version 1 sent
+// every message body as a JSON encoded string, version 2 a single item per
+// player and version 3 the PlayerConnected fields in lowercase.
+const SchemaVersion = 4
+
+type Enum string
+
+const (
+	Connection       Enum = "Connection"
+	Ping             Enum = "ping"
+	PlayerConnected  Enum = "PlayerConnected"
+	GameStart        Enum = "GameStart"
+	PlayerIsReady    Enum = "PlayerIsReady"
+	Phase            Enum = "Phase"
+	Fight            Enum = "Fight"
+	FightResult      Enum = "FightResult"
+	Update           Enum = "Update"
+	CommandRejected  Enum = "CommandRejected"
+	PlanningDeadline Enum = "PlanningDeadline"
+	ActionWithdrawn  Enum = "ActionWithdrawn"
+	ReadinessChanged Enum = "ReadinessChanged"
+	ChestRaided      Enum = "ChestRaided"
+	TeamRosters      Enum = "TeamRosters"
+	PlayerMoved      Enum = "PlayerMoved"
+	PlayerKicked     Enum = "PlayerKicked"
+	PlayerLeft       Enum = "PlayerLeft"
+	PlayerRenamed    Enum = "PlayerRenamed"
+)
+
+// Interface is implemented by every message sent to the clients.
+type Interface interface {
+	Type() Enum
+}
+
+// Envelope is the wire format of every message sent to the clients.
+type Envelope struct {
+	Version     int             `json:"Version"`
+	MessageType Enum            `json:"MessageType"`
+	Message     json.RawMessage `json:"Message"`
+}
+
+// All lists one value of every event, so that schemas can be generated from them.
+var All = []Interface{
+	LobbyConnectionStruct{},
+	ConnectionStruct{},
+	PingStruct{},
+	PlayerConnectedStruct{},
+	GameStartStruct{},
+	PlayerIsReadyStruct{},
+	PhaseStruct{},
+	FightStruct{},
+	FightResultStruct{},
+	UpdateStruct{},
+	CommandRejectedStruct{},
+	PlanningDeadlineStruct{},
+	ActionWithdrawnStruct{},
+	ReadinessChangedStruct{},
+	ChestRaidedStruct{},
+	TeamRostersStruct{},
+	PlayerMovedStruct{},
+	PlayerKickedStruct{},
+	PlayerLeftStruct{},
+	PlayerRenamedStruct{},
+}
+
+// Encode wraps the event into an Envelope and marshals it.
+func Encode(event Interface) ([]byte, error) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("Event.Encode: Could not marshal the %s message: %w", event.Type(), err)
+	}
+
+	envelope, err := json.Marshal(Envelope{SchemaVersion, event.Type(), message})
+	if err != nil {
+		return nil, fmt.Errorf("Event.Encode: Could not marshal the %s envelope: %w", event.Type(), err)
+	}
+
+	return envelope, nil
+}
@@ -0,0 +1,173 @@
+package Event
+
+import (
+	f "ChoHanJi/domain/Fight"
+	"ChoHanJi/domain/Item"
+	"ChoHanJi/domain/Map"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Readiness"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/domain/Team"
+	"ChoHanJi/domain/UpdateMessage"
+)
+
+// LobbyConnectionStruct is sent when a subscriber joins the waiting room.
+type LobbyConnectionStruct struct {
+	RoomId string `json:"RoomId"`
+}
+
+func (LobbyConnectionStruct) Type() Enum { return Connection }
+
+// ConnectionStruct is the snapshot sent when a subscriber joins the game.
+// Only the admin receives the readiness of the players.
+type ConnectionStruct struct {
+	MapHeight int               `json:"MapHeight"`
+	MapWidth  int               `json:"MapWidth"`
+	Tiles     []*Map.Tile       `json:"Tiles"`
+	Players   []*Player.Struct  `json:"Players"`
+	Items     []*Item.Struct    `json:"Items"`
+	Readiness *Readiness.Struct `json:"Readiness,omitempty"`
+	Teams     []Team.Struct     `json:"Teams"`
+}
+
+func (ConnectionStruct) Type() Enum { return Connection }
+
+type PingStruct struct{}
+
+func (PingStruct) Type() Enum { return Ping }
+
+type PlayerConnectedStruct struct {
+	Id   Player.Id `json:"Id"`
+	Name string    `json:"Name"`
+	Team int       `json:"Team"`
+}
+
+func (PlayerConnectedStruct) Type() Enum { return PlayerConnected }
+
+type GameStartStruct struct {
+	RoomId    string `json:"RoomId"`
+	MapHeight int    `json:"MapHeight"`
+	MapWidth  int    `json:"MapWidth"`
+}
+
+func (GameStartStruct) Type() Enum { return GameStart }
+
+type PlayerIsReadyStruct struct {
+	PlayerId Player.Id `json:"PlayerId"`
+}
+
+func (PlayerIsReadyStruct) Type() Enum { return PlayerIsReady }
+
+// ReadinessChangedStruct tells the admin who is ready for the current turn.
+type ReadinessChangedStruct struct {
+	*Readiness.Struct
+}
+
+func (ReadinessChangedStruct) Type() Enum { return ReadinessChanged }
+
+// ActionWithdrawnStruct tells the admin a player is no longer ready.
+type ActionWithdrawnStruct struct {
+	PlayerId Player.Id `json:"PlayerId"`
+}
+
+func (ActionWithdrawnStruct) Type() Enum { return ActionWithdrawn }
+
+type PhaseEnum string
+
+const (
+	ItemPhase                PhaseEnum = "Item"
+	AbilityPhase             PhaseEnum = "Ability"
+	AttackPhase              PhaseEnum = "Attack"
+	MovePhase                PhaseEnum = "Move"
+	BonusAttackPhase         PhaseEnum = "BonusAttack"
+	CollisionResolutionPhase PhaseEnum = "CollisionResolution"
+)
+
+type PhaseStruct struct {
+	Phase PhaseEnum `json:"Phase"`
+}
+
+func (PhaseStruct) Type() Enum { return Phase }
+
+// FightStruct announces a fight the participants have to play.
+type FightStruct struct {
+	*f.Struct
+}
+
+func (FightStruct) Type() Enum { return Fight }
+
+// FightResultStruct announces the winner once both participants agreed on it.
+type FightResultStruct struct {
+	*f.Struct
+}
+
+func (FightResultStruct) Type() Enum { return FightResult }
+
+// UpdateStruct carries the changes made to the board while resolving a turn.
+type UpdateStruct struct {
+	*UpdateMessage.Struct
+}
+
+func (UpdateStruct) Type() Enum { return Update }
+
+type CommandRejectedStruct struct {
+	Reason string `json:"Reason"`
+}
+
+func (CommandRejectedStruct) Type() Enum { return CommandRejected }
+
+// PlanningDeadlineStruct announces when the planning phase ends and the turn
+// proceeds on its own. The deadline is in milliseconds since the Unix epoch.
+type PlanningDeadlineStruct struct {
+	DeadlineUnixMilli int64 `json:"DeadlineUnixMilli"`
+	Seconds           int   `json:"Seconds"`
+}
+
+func (PlanningDeadlineStruct) Type() Enum { return PlanningDeadline }
+
+// ChestRaidedStruct tells everyone the treasure chest of the Team was raided
+// and its Items scattered over the map.
+type ChestRaidedStruct struct {
+	RaiderId Player.Id `json:"RaiderId"`
+	Team     int       `json:"Team"`
+	Items    []Item.Id `json:"Items"`
+}
+
+func (ChestRaidedStruct) Type() Enum { return ChestRaided }
+
+// TeamRostersStruct tells the admin who plays for which team in the lobby.
+type TeamRostersStruct struct {
+	Teams []Room.Roster `json:"Teams"`
+}
+
+func (TeamRostersStruct) Type() Enum { return TeamRosters }
+
+// PlayerMovedStruct tells a player the admin moved them to another team.
+type PlayerMovedStruct struct {
+	PlayerId Player.Id `json:"PlayerId"`
+	Team     int       `json:"Team"`
+}
+
+func (PlayerMovedStruct) Type() Enum { return PlayerMoved }
+
+// PlayerKickedStruct tells a player the admin kicked them out of the room.
+type PlayerKickedStruct struct {
+	PlayerId Player.Id `json:"PlayerId"`
+}
+
+func (PlayerKickedStruct) Type() Enum { return PlayerKicked }
+
+// PlayerLeftStruct tells a player they left the room.
+type PlayerLeftStruct struct {
+	PlayerId Player.Id `json:"PlayerId"`
+}
+
+func (PlayerLeftStruct) Type() Enum { return PlayerLeft }
+
+// PlayerRenamedStruct tells a player the name they now go by.
+type PlayerRenamedStruct struct {
+	PlayerId Player.Id `json:"PlayerId"`
+	Name     string    `json:"Name"`
+}
+
+func (PlayerRenamedStruct) Type() Enum { return PlayerRenamed }
@@ -0,0 +1,34 @@
+package Failure
+
+// Kind tells what went wrong, so that the drivers answer the errors without
+// knowing the packages they come from.
+type Kind string
+
+const (
+	// NotFound is the room, the player or the fight named that does not exist.
+	NotFound Kind = "not found"
+	// Conflict is a fine request the game is not in a state to take.
+	Conflict Kind = "conflict"
+	// Forbidden is a request the player may not make.
+	Forbidden Kind = "forbidden"
+	// Invalid is a wrong request.
+	Invalid Kind = "invalid"
+)
+
+func (k Kind) Error() string { return string(k) }
+
+// Error is a sentinel error of a kind.
+type Error struct {
+	kind Kind
+	text string
+}
+
+// New returns a sentinel error of the kind. errors.Is matches the errors
+// wrapping it against the sentinel and against its kind.
+func New(kind Kind, text string) error {
+	return &Error{kind, text}
+}
+
+func (e *Error) Error() string { return e.text }
+
+func (e *Error) Is(target error) bool { return target == e.kind }
@@ -1,6 +1,7 @@
 package Fight
 
 import (
+	"ChoHanJi/domain/Failure"
 	"ChoHanJi/domain/Game"
 	"ChoHanJi/domain/IdGenerator"
 	"ChoHanJi/domain/Player"
@@ -9,6 +10,11 @@ import (
 	"sync"
 )
 
+var (
+	ErrNotFound      = Failure.New(Failure.NotFound, "not found")
+	ErrNotInTheFight = Failure.New(Failure.Invalid, "not part of the fight")
+)
+
 type Id string
 
 type Struct struct {
@@ -79,12 +85,12 @@ func (cf *CurrentFights) RegisterResult(roomId Room.Id, fightId Id, submitterId
 
 	room, found := cf.currentFights[roomId]
 	if !found {
-		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: room not found")
+		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: %s %w", "room", ErrNotFound)
 	}
 
 	fight, found := room[fightId]
 	if !found {
-		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: fight not found")
+		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: %s %w", "fight", ErrNotFound)
 	}
 
 	if fight.resolved {
@@ -92,11 +98,11 @@ func (cf *CurrentFights) RegisterResult(roomId Room.Id, fightId Id, submitterId
 	}
 
 	if submitterId != fight.AttackerId && submitterId != fight.DefenderId {
-		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: player not part of fight")
+		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: %s %w", "player", ErrNotInTheFight)
 	}
 
 	if winnerId != fight.AttackerId && winnerId != fight.DefenderId {
-		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: winner not part of fight")
+		return nil, false, fmt.Errorf("CurrentFights.RegisterResult: %s %w", "winner", ErrNotInTheFight)
 	}
 
 	fight.submissions[submitterId] = struct{}{}
@@ -7,21 +7,71 @@ import (
 
 type Id string
 
+// Kind tells whether an item is carried to the chest or consumed.
+type Kind string
+
+const (
+	// Treasure is carried to the team's chest where it scores its Points.
+	Treasure Kind = "Treasure"
+	// Consumable applies its Effect to the player using it.
+	Consumable Kind = "Consumable"
+)
+
+// Effect is what a consumable does to the player consuming it.
+type Effect string
+
+const (
+	// Heal lets the player survive Magnitude lost fights.
+	Heal Effect = "Heal"
+	// SpeedBoost adds Magnitude tiles to the next move of the player.
+	SpeedBoost Effect = "SpeedBoost"
+	// Reveal lifts the fog of war for the player during Magnitude turns.
+	Reveal Effect = "Reveal"
+)
+
 type Struct struct {
 	X     int `json:"X"`
 	Y     int `json:"Y"`
 	Id    Id
 	IdStr string `json:"Id"`
 	Name  string `json:"Name"`
+	Kind  Kind   `json:"Kind"`
+	// Points is what the item scores once on its team's chest.
+	Points int `json:"Points"`
+	// Weight slows down the player carrying more than the carry weight of
+	// their class, by a tile per point above it.
+	Weight    int    `json:"Weight"`
+	Effect    Effect `json:"Effect,omitempty"`
+	Magnitude int    `json:"Magnitude,omitempty"`
 }
 
+// New creates a treasure worth a point.
 func New(name string) (*Struct, error) {
+	return FromSpec(Spec{Name: name, Kind: Treasure, Points: 1, Weight: 1})
+}
+
+// FromSpec creates an item of the type the spec declares.
+func FromSpec(spec Spec) (*Struct, error) {
 	strId, err := IdGenerator.NewId()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Struct{Id: Id(strId), IdStr: strId, Name: name}, nil
+	return &Struct{
+		Id:        Id(strId),
+		IdStr:     strId,
+		Name:      spec.Name,
+		Kind:      spec.Kind,
+		Points:    spec.Points,
+		Weight:    spec.Weight,
+		Effect:    spec.Effect,
+		Magnitude: spec.Magnitude,
+	}, nil
+}
+
+// IsConsumable reports whether the item is consumed on pickup.
+func (s *Struct) IsConsumable() bool {
+	return s.Kind == Consumable
 }
 
 func DecodeItems(itemList string) []*Struct {
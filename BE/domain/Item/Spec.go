@@ -0,0 +1,115 @@
+package Item
+
+import (
+	"ChoHanJi/domain/Failure"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var ErrInvalidSpec = Failure.New(Failure.Invalid, "invalid item spec")
+
+// Spec declares an item type and how many items of it a room starts with.
+type Spec struct {
+	Name string `json:"Name"`
+	// Kind defaults to Treasure.
+	Kind      Kind   `json:"Kind"`
+	Points    int    `json:"Points"`
+	Weight    int    `json:"Weight"`
+	Effect    Effect `json:"Effect"`
+	Magnitude int    `json:"Magnitude"`
+	// Count defaults to one.
+	Count int `json:"Count"`
+}
+
+// ParseSpecs reads the items of a room, either a list of specs or the legacy
+// comma separated names, each of them a treasure worth a point.
+func ParseSpecs(raw json.RawMessage) ([]Spec, error) {
+	var names string
+	if err := json.Unmarshal(raw, &names); err == nil {
+		var specs []Spec
+		for _, item := range DecodeItems(names) {
+			specs = append(specs, Spec{Name: item.Name, Kind: item.Kind, Points: item.Points, Weight: item.Weight})
+		}
+		return specs, nil
+	}
+
+	var specs []Spec
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&specs); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+
+	for i := range specs {
+		if specs[i].Kind == "" {
+			specs[i].Kind = Treasure
+		}
+		if err := specs[i].validate(); err != nil {
+			return nil, fmt.Errorf("%w: item %d: %w", ErrInvalidSpec, i, err)
+		}
+	}
+
+	return specs, nil
+}
+
+func (s Spec) validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("missing name")
+	}
+	if s.Points < 0 || s.Weight < 0 || s.Magnitude < 0 || s.Count < 0 {
+		return errors.New("negative value")
+	}
+
+	switch s.Kind {
+	case Treasure:
+		if s.Effect != "" {
+			return errors.New("a treasure has no effect")
+		}
+	case Consumable:
+		switch s.Effect {
+		case Heal, SpeedBoost, Reveal:
+		default:
+			return fmt.Errorf("unknown effect %q", s.Effect)
+		}
+		if s.Magnitude == 0 {
+			return errors.New("an effect needs a magnitude")
+		}
+	default:
+		return fmt.Errorf("unknown kind %q", s.Kind)
+	}
+
+	return nil
+}
+
+// Total counts the items the specs declare without making them, as many as
+// an int holds at most.
+func Total(specs []Spec) int {
+	total := 0
+	for _, spec := range specs {
+		count := max(spec.Count, 1)
+		if count > math.MaxInt-total {
+			return math.MaxInt
+		}
+		total += count
+	}
+	return total
+}
+
+// Create makes the items the specs declare.
+func Create(specs []Spec) ([]*Struct, error) {
+	var items []*Struct
+	for _, spec := range specs {
+		count := max(spec.Count, 1)
+		for range count {
+			item, err := FromSpec(spec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,173 @@
+package Map
+
+import (
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Team"
+	"ChoHanJi/domain/TileFlag"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidLayout = Failure.New(Failure.Invalid, "invalid layout")
+	ErrNoSpawn       = errors.New("no spawn for the team")
+)
+
+type Point struct {
+	X int `json:"X"`
+	Y int `json:"Y"`
+}
+
+// Layout places the treasure chests, the spawns and the walls of the map.
+// Without chests or without spawns, the teams get theirs around the map.
+type Layout struct {
+	// Chests gives the treasure chest of every team.
+	Chests map[Team.Enum]Point `json:"Chests"`
+	// Spawns lists the spawn tiles of every team.
+	Spawns map[Team.Enum][]Point `json:"Spawns"`
+	// Inaccessible lists the tiles nobody can enter.
+	Inaccessible []Point `json:"Inaccessible"`
+}
+
+// ringSpot is the tile k/n of the way around the ring one tile inside the
+// edges of the map, clockwise from the top left corner. Every side is a
+// quarter of the way, so four spots fall on the corners of the ring.
+func ringSpot(width, height, k, n int) Point {
+	right, bottom := width-2, height-2
+	across, down := right-1, bottom-1
+
+	side, rest := 4*k/n, 4*k%n
+	switch side {
+	case 0:
+		return Point{1 + across*rest/n, 1}
+	case 1:
+		return Point{right, 1 + down*rest/n}
+	case 2:
+		return Point{right - across*rest/n, bottom}
+	default:
+		return Point{1, bottom - down*rest/n}
+	}
+}
+
+// defaultChest and defaultSpawn share the ring between the teams, each chest
+// followed by the spawn of its team. Two teams get the corners.
+func defaultChest(width, height, i, teams int) Point {
+	return ringSpot(width, height, 2*i, 2*teams)
+}
+
+func defaultSpawn(width, height, i, teams int) Point {
+	return ringSpot(width, height, 2*i+1, 2*teams)
+}
+
+// applyLayout flags the chests, the spawns and the inaccessible tiles,
+// refusing the layouts leaving a team without a chest or a spawn.
+func (m *Map) applyLayout(layout Layout) error {
+	if err := m.checkTeams(layout); err != nil {
+		return err
+	}
+
+	width, height := len(m.tiles), len(m.tiles[0])
+
+	m.chests = make(map[Team.Enum]*Tile, len(m.teams))
+	for i, team := range m.teams {
+		point := defaultChest(width, height, i, len(m.teams))
+		if len(layout.Chests) > 0 {
+			chest, found := layout.Chests[team]
+			if !found {
+				return fmt.Errorf("%w: team %d has no chest", ErrInvalidLayout, team)
+			}
+			point = chest
+		}
+
+		tile, err := m.flag(point, TileFlag.TREASURE_CHEST, team, "chest")
+		if err != nil {
+			return err
+		}
+		m.chests[team] = tile
+	}
+
+	m.spawns = make(map[Team.Enum][]*Tile, len(m.teams))
+	for i, team := range m.teams {
+		points := []Point{defaultSpawn(width, height, i, len(m.teams))}
+		if len(layout.Spawns) > 0 {
+			points = layout.Spawns[team]
+		}
+		if len(points) == 0 {
+			return fmt.Errorf("%w %d", ErrNoSpawn, team)
+		}
+
+		for _, point := range points {
+			tile, err := m.flag(point, TileFlag.SPAWN, team, "spawn")
+			if err != nil {
+				return err
+			}
+			m.spawns[team] = append(m.spawns[team], tile)
+		}
+	}
+
+	for _, point := range layout.Inaccessible {
+		tile, err := m.GetTile(point.X, point.Y)
+		if err != nil {
+			return fmt.Errorf("%w: inaccessible tile %d, %d: %w", ErrInvalidLayout, point.X, point.Y, err)
+		}
+		if tile.Flag != TileFlag.EMPTY && tile.Flag != TileFlag.INACCESSIBLE {
+			return fmt.Errorf("%w: tile %d, %d cannot be inaccessible", ErrInvalidLayout, point.X, point.Y)
+		}
+		tile.Flag = TileFlag.INACCESSIBLE
+	}
+
+	return nil
+}
+
+// checkTeams refuses the chests and spawns of teams not playing.
+func (m *Map) checkTeams(layout Layout) error {
+	for team := range layout.Chests {
+		if !slices.Contains(m.teams, team) {
+			return fmt.Errorf("%w: team %d is not playing", ErrInvalidLayout, team)
+		}
+	}
+	for team := range layout.Spawns {
+		if !slices.Contains(m.teams, team) {
+			return fmt.Errorf("%w: team %d is not playing", ErrInvalidLayout, team)
+		}
+	}
+	return nil
+}
+
+// flag gives the empty tile at the point to the team.
+func (m *Map) flag(point Point, flag TileFlag.TileFlagEnum, team Team.Enum, what string) (*Tile, error) {
+	tile, err := m.GetTile(point.X, point.Y)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %d, %d: %w", ErrInvalidLayout, what, point.X, point.Y, err)
+	}
+	if tile.Flag != TileFlag.EMPTY {
+		return nil, fmt.Errorf("%w: %s %d, %d is already taken", ErrInvalidLayout, what, point.X, point.Y)
+	}
+
+	tile.Flag = flag
+	tile.Team = team
+	return tile, nil
+}
+
+// nextSpawn picks the least crowded spawn of the team, taking turns between
+// the spawns as crowded as each other.
+func (m *Map) nextSpawn(team Team.Enum) (*Tile, error) {
+	spawns := m.spawns[team]
+	if len(spawns) == 0 {
+		return nil, fmt.Errorf("%w %d", ErrNoSpawn, team)
+	}
+
+	start := m.spawnTurn[team]
+	var chosen *Tile
+	chosenIndex := 0
+	for i := range spawns {
+		index := (start + i) % len(spawns)
+		if chosen == nil || len(spawns[index].Player) < len(chosen.Player) {
+			chosen, chosenIndex = spawns[index], index
+		}
+	}
+	m.spawnTurn[team] = chosenIndex + 1
+
+	return chosen, nil
+}
@@ -4,22 +4,40 @@ import (
 	"ChoHanJi/domain/Item"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/Team"
-	"ChoHanJi/domain/TileFlag"
 	"errors"
-	"math/rand"
+	"fmt"
+	"slices"
+	"sync"
 )
 
 type Map struct {
-	tiles [][]*Tile
+	tiles   [][]*Tile
+	scatter Scatter
+	teams   []Team.Enum
+	chests  map[Team.Enum]*Tile
+
+	lock      sync.Mutex
+	spawns    map[Team.Enum][]*Tile
+	spawnTurn map[Team.Enum]int
+	placed    map[Player.Id]struct{}
 }
 
-func NewMap(width, height int, items []*Item.Struct) (*Map, error) {
-	if width <= 0 || height <= 0 {
-		return nil, errors.New("width and height must be > 0")
+// NewMap creates the map of the teams with the layout and scatters the items
+// over it following the rules.
+func NewMap(width, height int, teams []Team.Enum, items []*Item.Struct, scatter Scatter, layout Layout) (*Map, error) {
+	if err := Validate(width, height, teams, len(items), layout); err != nil {
+		return nil, err
+	}
+	if err := scatter.Validate(); err != nil {
+		return nil, err
 	}
 
 	fieldMap := &Map{
-		tiles: make([][]*Tile, width),
+		tiles:     make([][]*Tile, width),
+		scatter:   scatter,
+		teams:     slices.Clone(teams),
+		spawnTurn: make(map[Team.Enum]int),
+		placed:    make(map[Player.Id]struct{}),
 	}
 
 	// Allocate 2D slice
@@ -30,24 +48,19 @@ func NewMap(width, height int, items []*Item.Struct) (*Map, error) {
 		}
 	}
 
-	// Set the Flags
-	fieldMap.tiles[1][1].Flag = TileFlag.TREASURE_CHEST
-	fieldMap.tiles[1][1].Team = Team.Team1
-	fieldMap.tiles[width-2][1].Flag = TileFlag.SPAWN
-	fieldMap.tiles[width-2][1].Team = Team.Team1
-	fieldMap.tiles[width-2][height-2].Flag = TileFlag.TREASURE_CHEST
-	fieldMap.tiles[width-2][height-2].Team = Team.Team2
-	fieldMap.tiles[1][height-2].Flag = TileFlag.SPAWN
-	fieldMap.tiles[1][height-2].Team = Team.Team2
+	if err := fieldMap.applyLayout(layout); err != nil {
+		return nil, err
+	}
+
+	fieldMap.claimTerritories()
 
-	empty := fieldMap.getEmptyTileCoords()
-	if len(empty) == 0 && len(items) > 0 {
-		return nil, errors.New("no empty tiles available for item placement")
+	spots, err := fieldMap.scatterTiles(nil, len(items))
+	if err != nil {
+		return nil, fmt.Errorf("no empty tiles available for item placement: %w", err)
 	}
 
-	for _, item := range items {
-		xy := empty[rand.Intn(len(empty))]
-		x, y := xy[0], xy[1]
+	for i, item := range items {
+		x, y := spots[i][0], spots[i][1]
 
 		item.X = x
 		item.Y = y
@@ -57,6 +70,8 @@ func NewMap(width, height int, items []*Item.Struct) (*Map, error) {
 	return fieldMap, nil
 }
 
+// PlacePlayer puts the player on the least crowded spawn of their team. A
+// player already placed, reconnecting, stays where they are.
 func (m *Map) PlacePlayer(player *Player.Struct) error {
 	if m == nil || !m.IsInitialized() {
 		return errors.New("map not initialized")
@@ -65,7 +80,14 @@ func (m *Map) PlacePlayer(player *Player.Struct) error {
 		return errors.New("player is nil")
 	}
 
-	spawnTile, err := m.findSpawnTile(Team.Enum(player.TeamNumber))
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, found := m.placed[player.Id]; found {
+		return nil
+	}
+
+	spawnTile, err := m.nextSpawn(Team.Enum(player.TeamNumber))
 	if err != nil {
 		return err
 	}
@@ -73,21 +95,10 @@ func (m *Map) PlacePlayer(player *Player.Struct) error {
 	spawnTile.AddPlayer(player)
 	player.X = spawnTile.X
 	player.Y = spawnTile.Y
+	m.placed[player.Id] = struct{}{}
 	return nil
 }
 
-func (m *Map) findSpawnTile(team Team.Enum) (*Tile, error) {
-	for _, column := range m.tiles {
-		for _, tile := range column {
-			if tile.Flag == TileFlag.SPAWN && tile.Team == team {
-				return tile, nil
-			}
-		}
-	}
-
-	return nil, errors.New("spawn point not found for team")
-}
-
 func (m *Map) GetMapWidth() (int, error) {
 	if m == nil || !m.IsInitialized() {
 		return 0, errors.New("map not initialized")
@@ -154,26 +165,78 @@ func (m *Map) GetNonEmptyTiles() []*Tile {
 	return tiles
 }
 
-func (m *Map) GetSpawn(team Team.Enum) (int, int) {
-	width, _ := m.GetMapWidth()
-	height, _ := m.GetMapHeight()
-	if team == Team.Team1 {
-		return width - 2, 1
-	} else {
-		return 1, height - 2
+// Hold counts the player as placed while keeping them off the map, until they
+// spawn the way the dead players respawn.
+func (m *Map) Hold(player *Player.Struct) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.placed[player.Id] = struct{}{}
+	player.X, player.Y = Player.OffBoard, Player.OffBoard
+}
+
+// RemovePlayer takes the player off the map and reports whether they were on
+// it. Placing them again puts them back on a spawn.
+func (m *Map) RemovePlayer(player *Player.Struct) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, found := m.placed[player.Id]; !found {
+		return false
+	}
+	delete(m.placed, player.Id)
+
+	if tile, err := m.GetTile(player.X, player.Y); err == nil {
+		tile.RemovePlayer(player.Id)
 	}
+	return true
+}
+
+// GetSpawn returns the spawn a player of the team respawns on, the least
+// crowded one.
+func (m *Map) GetSpawn(team Team.Enum) (int, int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tile, err := m.nextSpawn(team)
+	if err != nil {
+		return 0, 0, err
+	}
+	return tile.X, tile.Y, nil
 }
 
+// Teams lists the teams playing on the map.
+func (m *Map) Teams() []Team.Enum {
+	return slices.Clone(m.teams)
+}
+
+// GetTeamTreasureChestLocation returns where the treasure chest of the team
+// is, off the map when the team is not playing.
 func (m *Map) GetTeamTreasureChestLocation(team Team.Enum) (int, int) {
-	width, _ := m.GetMapWidth()
-	height, _ := m.GetMapHeight()
-	if team == Team.Team1 {
-		return 1, 1
-	} else {
-		return width - 2, height - 2
+	chest, found := m.chests[team]
+	if !found {
+		return -1, -1
 	}
+	return chest.X, chest.Y
 }
 
+// Score adds up the points of the items on the treasure chest of the team.
+func (m *Map) Score(team Team.Enum) int {
+	x, y := m.GetTeamTreasureChestLocation(team)
+	tile, err := m.GetTile(x, y)
+	if err != nil {
+		return 0
+	}
+
+	score := 0
+	for _, item := range tile.Items {
+		score += item.Points
+	}
+	return score
+}
+
+// DisperseItems scatters the items of the treasure chest of the team over the
+// map following its scatter rules.
 func (m *Map) DisperseItems(team Team.Enum) ([]*Item.Struct, error) {
 	x, y := m.GetTeamTreasureChestLocation(team)
 	tile, err := m.GetTile(x, y)
@@ -186,15 +249,14 @@ func (m *Map) DisperseItems(team Team.Enum) ([]*Item.Struct, error) {
 		return nil, nil
 	}
 
-	empty := m.getEmptyTileCoords()
-	if len(empty) == 0 {
-		return nil, errors.New("no empty tiles available for dispersing items")
+	spots, err := m.scatterTiles(&coords{x, y}, len(items))
+	if err != nil {
+		return nil, err
 	}
 
 	var itemsMoved []*Item.Struct
-	for _, item := range items {
-		xy := empty[rand.Intn(len(empty))]
-		x, y := xy[0], xy[1]
+	for i, item := range items {
+		x, y := spots[i][0], spots[i][1]
 
 		item.X = x
 		item.Y = y
@@ -205,15 +267,3 @@ func (m *Map) DisperseItems(team Team.Enum) ([]*Item.Struct, error) {
 	tile.Items = tile.Items[:0]
 	return itemsMoved, nil
 }
-
-func (m *Map) getEmptyTileCoords() [][2]int {
-	var coords [][2]int
-	for x := range m.tiles {
-		for y := range m.tiles[x] {
-			if m.tiles[x][y].Flag == TileFlag.EMPTY {
-				coords = append(coords, [2]int{x, y})
-			}
-		}
-	}
-	return coords
-}
@@ -0,0 +1,254 @@
+package Map
+
+import (
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Team"
+	"ChoHanJi/domain/TileFlag"
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+var ErrInvalidScatter = Failure.New(Failure.Invalid, "invalid scatter rules")
+
+// Strategy chooses the tiles the items are scattered on.
+type Strategy string
+
+const (
+	// Anywhere picks any empty tile. It is the default.
+	Anywhere Strategy = "Anywhere"
+	// Radius picks the empty tiles at most Radius steps away from the chest.
+	Radius Strategy = "Radius"
+	// Nearest fills the empty tiles closest to the chest first.
+	Nearest Strategy = "Nearest"
+	// Neutral picks the empty tiles no team owns.
+	Neutral Strategy = "Neutral"
+)
+
+// neutralMargin is how much closer to the base of a team than to the others
+// a tile has to be for the team to own it.
+const neutralMargin = 2
+
+// Scatter holds the rules placing the items on the map, at its creation and
+// when a chest is raided. Radius and Nearest are measured from the raided
+// chest and place the items of a new map Anywhere.
+type Scatter struct {
+	Strategy Strategy `json:"Strategy"`
+	// Radius bounds the Radius strategy.
+	Radius int `json:"Radius"`
+	// OnePerTile keeps the items from piling up while there are free tiles.
+	OnePerTile bool `json:"OnePerTile"`
+}
+
+// Validate fills in the default strategy and checks the rules.
+func (s *Scatter) Validate() error {
+	switch s.Strategy {
+	case "":
+		s.Strategy = Anywhere
+	case Anywhere, Nearest, Neutral:
+	case Radius:
+		if s.Radius < 1 {
+			return fmt.Errorf("%w: the Radius strategy needs a radius of at least 1", ErrInvalidScatter)
+		}
+	default:
+		return fmt.Errorf("%w: unknown strategy %q", ErrInvalidScatter, s.Strategy)
+	}
+	if s.Radius < 0 {
+		return fmt.Errorf("%w: negative radius", ErrInvalidScatter)
+	}
+	return nil
+}
+
+type coords = [2]int
+
+// claimTerritories gives every empty tile to the team whose base, its chest
+// or its spawns, is clearly the fewest steps away, and leaves the others
+// neutral. The steps go around the walls, so a tile behind one belongs to the
+// team that can walk to it.
+func (m *Map) claimTerritories() {
+	distances := make([]map[coords]int, len(m.teams))
+	for i, team := range m.teams {
+		chest := m.chests[team]
+		distances[i] = m.distancesFrom(append(m.spawnCoords(team), coords{chest.X, chest.Y}))
+	}
+
+	for x := range m.tiles {
+		for y := range m.tiles[x] {
+			tile := m.tiles[x][y]
+			if tile.Flag != TileFlag.EMPTY {
+				continue
+			}
+
+			owner, closest, runnerUp := Team.NEUTRAL, -1, -1
+			for i, team := range m.teams {
+				d, found := distances[i][coords{x, y}]
+				if !found {
+					continue
+				}
+				switch {
+				case closest < 0 || d < closest:
+					owner, closest, runnerUp = team, d, closest
+				case runnerUp < 0 || d < runnerUp:
+					runnerUp = d
+				}
+			}
+			if runnerUp >= 0 && runnerUp-closest <= neutralMargin {
+				owner = Team.NEUTRAL
+			}
+			tile.Team = owner
+		}
+	}
+}
+
+// scatterTiles picks a tile for each of n items following the rules. origin
+// is the raided chest, or nil when the map is created. Whenever the rules run
+// out of tiles, the items go to any empty tile reachable from the origin, or
+// from a spawn when the map is created.
+func (m *Map) scatterTiles(origin *coords, n int) ([]coords, error) {
+	reachable := m.reachableEmptyTiles(origin)
+	if len(reachable) == 0 {
+		if n == 0 {
+			return nil, nil
+		}
+		return nil, errors.New("no empty tiles available for scattering items")
+	}
+
+	candidates := reachable
+	switch m.scatter.Strategy {
+	case Radius:
+		if origin != nil {
+			candidates = slices.DeleteFunc(slices.Clone(reachable), func(c coords) bool {
+				return abs(c[0]-origin[0])+abs(c[1]-origin[1]) > m.scatter.Radius
+			})
+		}
+	case Neutral:
+		candidates = slices.DeleteFunc(slices.Clone(reachable), func(c coords) bool {
+			return m.tiles[c[0]][c[1]].Team != Team.NEUTRAL
+		})
+	}
+	ordered := m.scatter.Strategy == Nearest && origin != nil
+
+	picked := make([]coords, 0, n)
+	taken := make(map[coords]int)
+	free := func(c coords) bool {
+		if !m.scatter.OnePerTile {
+			return true
+		}
+		return taken[c] == 0 && len(m.tiles[c[0]][c[1]].Items) == 0
+	}
+
+	for range n {
+		pool := slices.DeleteFunc(slices.Clone(candidates), func(c coords) bool { return !free(c) })
+		if len(pool) == 0 {
+			pool = slices.DeleteFunc(slices.Clone(reachable), func(c coords) bool { return !free(c) })
+		}
+		if len(pool) == 0 {
+			pool = candidates
+		}
+		if len(pool) == 0 {
+			pool = reachable
+		}
+
+		// Nearest gives every item a tile of its own, the closest first, and
+		// starts over from the closest once it went through them all.
+		var c coords
+		if ordered {
+			c = slices.MinFunc(pool, func(a, b coords) int { return cmp.Compare(taken[a], taken[b]) })
+		} else {
+			c = pool[rand.Intn(len(pool))]
+		}
+		picked = append(picked, c)
+		taken[c]++
+	}
+
+	return picked, nil
+}
+
+// reachableEmptyTiles lists the empty tiles a player can walk to from the
+// origin, the closest first. Without an origin, the items of a new map only
+// go where a player can walk to from a spawn.
+func (m *Map) reachableEmptyTiles(origin *coords) []coords {
+	var starts []coords
+	if origin != nil {
+		starts = []coords{*origin}
+	} else {
+		for _, team := range m.teams {
+			starts = append(starts, m.spawnCoords(team)...)
+		}
+	}
+	distances := m.distancesFrom(starts)
+
+	var empty []coords
+	for c := range distances {
+		if m.tiles[c[0]][c[1]].Flag == TileFlag.EMPTY {
+			empty = append(empty, c)
+		}
+	}
+	slices.SortFunc(empty, func(a, b coords) int {
+		return cmp.Or(
+			cmp.Compare(distances[a], distances[b]),
+			cmp.Compare(a[0], b[0]),
+			cmp.Compare(a[1], b[1]),
+		)
+	})
+	return empty
+}
+
+// distancesFrom walks the map from the closest of the starts and returns the
+// number of steps to every tile a player can reach.
+func (m *Map) distancesFrom(starts []coords) map[coords]int {
+	return walk(starts, func(c coords) bool {
+		tile, err := m.GetTile(c[0], c[1])
+		return err == nil && tile.Flag != TileFlag.INACCESSIBLE
+	})
+}
+
+// walk steps from the closest of the starts to the open tiles next to each
+// other and returns the number of steps to every tile it reaches.
+func walk(starts []coords, open func(coords) bool) map[coords]int {
+	distances := make(map[coords]int, len(starts))
+	queue := make([]coords, 0, len(starts))
+	for _, start := range starts {
+		if _, seen := distances[start]; !seen {
+			distances[start] = 0
+			queue = append(queue, start)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, step := range []coords{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			next := coords{current[0] + step[0], current[1] + step[1]}
+			if !open(next) {
+				continue
+			}
+			if _, seen := distances[next]; seen {
+				continue
+			}
+			distances[next] = distances[current] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	return distances
+}
+
+// spawnCoords lists the spawns of the team.
+func (m *Map) spawnCoords(team Team.Enum) []coords {
+	spawns := make([]coords, 0, len(m.spawns[team]))
+	for _, spawn := range m.spawns[team] {
+		spawns = append(spawns, coords{spawn.X, spawn.Y})
+	}
+	return spawns
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,157 @@
+package Map
+
+import (
+	"ChoHanJi/domain/Team"
+	"ChoHanJi/domain/TileFlag"
+	"slices"
+	"testing"
+)
+
+func newMap(t *testing.T, width, height int, scatter Scatter, layout Layout) *Map {
+	t.Helper()
+
+	m, err := NewMap(width, height, []Team.Enum{Team.Team1, Team.Team2}, nil, scatter, layout)
+	if err != nil {
+		t.Fatalf("NewMap() error = %v", err)
+	}
+	return m
+}
+
+func TestScatterTiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		// n is how many items are scattered, as a share of the reachable
+		// tiles for the cases going round them.
+		n    func(reachable int) int
+		want func(t *testing.T, m *Map, reachable, picked []coords, onePerTile bool)
+	}{
+		{
+			name:     "anywhere",
+			strategy: Anywhere,
+			n:        func(reachable int) int { return reachable / 2 },
+			want: func(t *testing.T, m *Map, reachable, picked []coords, onePerTile bool) {
+				for _, c := range picked {
+					if !slices.Contains(reachable, c) {
+						t.Fatalf("picked %v, want a reachable empty tile", c)
+					}
+				}
+				if onePerTile && distinct(picked) != len(picked) {
+					t.Fatalf("picked %v, want one item per tile", picked)
+				}
+			},
+		},
+		{
+			name:     "nearest",
+			strategy: Nearest,
+			n:        func(reachable int) int { return reachable / 2 },
+			want: func(t *testing.T, m *Map, reachable, picked []coords, onePerTile bool) {
+				if !slices.Equal(picked, reachable[:len(picked)]) {
+					t.Fatalf("picked %v, want the closest tiles %v", picked, reachable[:len(picked)])
+				}
+			},
+		},
+		{
+			name:     "nearest going round the tiles",
+			strategy: Nearest,
+			n:        func(reachable int) int { return reachable + 2 },
+			want: func(t *testing.T, m *Map, reachable, picked []coords, onePerTile bool) {
+				want := append(slices.Clone(reachable), reachable[:2]...)
+				if !slices.Equal(picked, want) {
+					t.Fatalf("picked %v, want %v", picked, want)
+				}
+			},
+		},
+		{
+			name:     "neutral",
+			strategy: Neutral,
+			n:        func(int) int { return 4 },
+			want: func(t *testing.T, m *Map, reachable, picked []coords, onePerTile bool) {
+				for _, c := range picked {
+					if m.tiles[c[0]][c[1]].Team != Team.NEUTRAL {
+						t.Fatalf("picked %v of team %d, want a neutral tile", c, m.tiles[c[0]][c[1]].Team)
+					}
+				}
+				if onePerTile && distinct(picked) != len(picked) {
+					t.Fatalf("picked %v, want one item per tile", picked)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, onePerTile := range []bool{false, true} {
+			name := tt.name
+			if onePerTile {
+				name += ", one per tile"
+			}
+			t.Run(name, func(t *testing.T) {
+				m := newMap(t, 10, 10, Scatter{Strategy: tt.strategy, OnePerTile: onePerTile}, Layout{})
+				x, y := m.GetTeamTreasureChestLocation(Team.Team1)
+				origin := coords{x, y}
+
+				reachable := m.reachableEmptyTiles(&origin)
+				picked, err := m.scatterTiles(&origin, tt.n(len(reachable)))
+				if err != nil {
+					t.Fatalf("scatterTiles() error = %v", err)
+				}
+				if len(picked) != tt.n(len(reachable)) {
+					t.Fatalf("picked %d tiles, want %d", len(picked), tt.n(len(reachable)))
+				}
+				tt.want(t, m, reachable, picked, onePerTile)
+			})
+		}
+	}
+}
+
+func TestScatterTilesSkipsWalledOffTiles(t *testing.T) {
+	walls := []Point{{4, 4}, {5, 4}, {6, 4}, {4, 5}, {6, 5}, {4, 6}, {5, 6}, {6, 6}}
+	m := newMap(t, 10, 10, Scatter{}, Layout{Inaccessible: walls})
+
+	picked, err := m.scatterTiles(nil, 500)
+	if err != nil {
+		t.Fatalf("scatterTiles() error = %v", err)
+	}
+	if slices.Contains(picked, coords{5, 5}) {
+		t.Fatal("picked the walled-off tile 5, 5")
+	}
+}
+
+func TestClaimTerritoriesWalksAroundWalls(t *testing.T) {
+	// A wall along x = 2 leaves team 1 a long way round to the tiles behind it.
+	var walls []Point
+	for y := range 7 {
+		walls = append(walls, Point{2, y})
+	}
+	m := newMap(t, 12, 8, Scatter{}, Layout{
+		Chests:       map[Team.Enum]Point{Team.Team1: {1, 1}, Team.Team2: {10, 1}},
+		Spawns:       map[Team.Enum][]Point{Team.Team1: {{1, 2}}, Team.Team2: {{10, 2}}},
+		Inaccessible: walls,
+	})
+
+	tests := []struct {
+		x, y int
+		want Team.Enum
+	}{
+		{1, 4, Team.Team1},
+		{3, 1, Team.Team2},
+		{9, 4, Team.Team2},
+	}
+	for _, tt := range tests {
+		tile := m.tiles[tt.x][tt.y]
+		if tile.Flag != TileFlag.EMPTY {
+			t.Fatalf("tile %d, %d is not empty", tt.x, tt.y)
+		}
+		if tile.Team != tt.want {
+			t.Errorf("tile %d, %d belongs to team %d, want %d", tt.x, tt.y, tile.Team, tt.want)
+		}
+	}
+}
+
+func distinct(picked []coords) int {
+	seen := make(map[coords]struct{})
+	for _, c := range picked {
+		seen[c] = struct{}{}
+	}
+	return len(seen)
+}
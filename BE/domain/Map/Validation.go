@@ -0,0 +1,173 @@
+package Map
+
+import (
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Team"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// MinSize is the narrowest and shortest a map may be, leaving room for
+	// the ring of chests and spawns inside the edges.
+	MinSize = 4
+	// MaxSize is the widest and tallest a map may be.
+	MaxSize = 100
+)
+
+var ErrInvalidMap = Failure.New(Failure.Invalid, "invalid map")
+
+// Violation is a rule of the maps a room breaks.
+type Violation struct {
+	// Field is the part of the room breaking the rule, such as MapWidth.
+	Field string `json:"Field"`
+	// Rule names the rule broken, such as min or overlap.
+	Rule   string `json:"Rule"`
+	Detail string `json:"Detail"`
+}
+
+// Violations lists every rule a room breaks, so that the admin fixes them all
+// at once. It is an ErrInvalidMap.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	details := make([]string, len(v))
+	for i, violation := range v {
+		details[i] = violation.Detail
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidMap, strings.Join(details, "; "))
+}
+
+func (v Violations) Is(target error) bool {
+	return errors.Is(ErrInvalidMap, target)
+}
+
+func (v *Violations) add(field, rule, detail string, args ...any) {
+	*v = append(*v, Violation{field, rule, fmt.Sprintf(detail, args...)})
+}
+
+// Validate checks a map of the size can be made for the teams, with the items
+// and the layout, before anything is allocated. It returns the Violations, or
+// nil when the map can be made.
+func Validate(width, height int, teams []Team.Enum, items int, layout Layout) error {
+	var v Violations
+
+	sized := true
+	for _, side := range []struct {
+		field string
+		size  int
+	}{{"MapWidth", width}, {"MapHeight", height}} {
+		switch {
+		case side.size < MinSize:
+			v.add(side.field, "min", "%s is %d, the maps are %d tiles at least", side.field, side.size, MinSize)
+			sized = false
+		case side.size > MaxSize:
+			v.add(side.field, "max", "%s is %d, the maps are %d tiles at most", side.field, side.size, MaxSize)
+			sized = false
+		}
+	}
+
+	for _, team := range slices.Sorted(maps.Keys(layout.Chests)) {
+		if !slices.Contains(teams, team) {
+			v.add("Layout.Chests", "team", "team %d has a chest but is not playing", team)
+		}
+	}
+	for _, team := range slices.Sorted(maps.Keys(layout.Spawns)) {
+		if !slices.Contains(teams, team) {
+			v.add("Layout.Spawns", "team", "team %d has spawns but is not playing", team)
+		}
+	}
+
+	// Where the tiles are only makes sense on a map of a sensible size.
+	if !sized {
+		return v
+	}
+
+	taken := make(map[Point]string)
+	take := func(field string, point Point, what string) {
+		if point.X < 0 || point.X >= width || point.Y < 0 || point.Y >= height {
+			v.add(field, "bounds", "%s at %d, %d is off the map", what, point.X, point.Y)
+			return
+		}
+		if other, found := taken[point]; found {
+			v.add(field, "overlap", "%s at %d, %d is already the %s", what, point.X, point.Y, other)
+			return
+		}
+		taken[point] = what
+	}
+
+	// The default chests and spawns overlap when the map is too small for the
+	// teams to share its ring.
+	chests := make(map[Team.Enum]Point, len(teams))
+	spawns := make(map[Team.Enum][]Point, len(teams))
+	for i, team := range teams {
+		point, what := defaultChest(width, height, i, len(teams)), "default chest"
+		if len(layout.Chests) > 0 {
+			chest, found := layout.Chests[team]
+			if !found {
+				v.add("Layout.Chests", "missing", "team %d has no chest", team)
+				continue
+			}
+			point, what = chest, "chest"
+		}
+		take("Layout.Chests", point, fmt.Sprintf("%s of team %d", what, team))
+		chests[team] = point
+	}
+
+	for i, team := range teams {
+		points, what := []Point{defaultSpawn(width, height, i, len(teams))}, "default spawn"
+		if len(layout.Spawns) > 0 {
+			points, what = layout.Spawns[team], "spawn"
+		}
+		if len(points) == 0 {
+			v.add("Layout.Spawns", "missing", "team %d has no spawn", team)
+		}
+		for _, point := range points {
+			take("Layout.Spawns", point, fmt.Sprintf("%s of team %d", what, team))
+		}
+		spawns[team] = points
+	}
+
+	blocked := make(map[Point]struct{})
+	for _, point := range layout.Inaccessible {
+		if _, found := blocked[point]; found {
+			continue
+		}
+		blocked[point] = struct{}{}
+		take("Layout.Inaccessible", point, "inaccessible tile")
+	}
+
+	if free := width*height - len(taken); items > free {
+		v.add("Items", "max", "%d items do not fit on the %d free tiles", items, free)
+	}
+
+	// The walls must leave every spawn a way to the chest of its team.
+	onMap := func(c coords) bool { return c[0] >= 0 && c[0] < width && c[1] >= 0 && c[1] < height }
+	open := func(c coords) bool {
+		_, found := blocked[Point{c[0], c[1]}]
+		return onMap(c) && !found
+	}
+	for _, team := range teams {
+		chest, found := chests[team]
+		if !found || !onMap(coords{chest.X, chest.Y}) {
+			continue
+		}
+		for _, spawn := range spawns[team] {
+			start := coords{spawn.X, spawn.Y}
+			if !onMap(start) {
+				continue
+			}
+			if _, found := walk([]coords{start}, open)[coords{chest.X, chest.Y}]; !found {
+				v.add("Layout.Inaccessible", "unreachable", "the walls cut the spawn at %d, %d off the chest of team %d", spawn.X, spawn.Y, team)
+			}
+		}
+	}
+
+	if len(v) > 0 {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package Map
+
+import (
+	"ChoHanJi/domain/Team"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	teams := []Team.Enum{Team.Team1, Team.Team2}
+	// pocket walls the tile 5, 5 in.
+	pocket := []Point{{4, 4}, {5, 4}, {6, 4}, {4, 5}, {6, 5}, {4, 6}, {5, 6}, {6, 6}}
+	layout := func(chest1 Point, spawn1 ...Point) Layout {
+		return Layout{
+			Chests:       map[Team.Enum]Point{Team.Team1: chest1, Team.Team2: {8, 8}},
+			Spawns:       map[Team.Enum][]Point{Team.Team1: spawn1, Team.Team2: {{8, 1}}},
+			Inaccessible: pocket,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		width, height int
+		items         int
+		layout        Layout
+		// want lists the field and rule of every violation, nil when the map
+		// can be made.
+		want []Violation
+	}{
+		{
+			name:  "the default layout",
+			width: 10, height: 10,
+		},
+		{
+			name:  "the smallest map",
+			width: MinSize, height: MinSize,
+		},
+		{
+			name:  "the biggest map",
+			width: MaxSize, height: MaxSize,
+		},
+		{
+			name:  "too narrow and too tall",
+			width: MinSize - 1, height: MaxSize + 1,
+			want: []Violation{{Field: "MapWidth", Rule: "min"}, {Field: "MapHeight", Rule: "max"}},
+		},
+		{
+			name:  "as many items as free tiles",
+			width: MinSize, height: MinSize,
+			items: MinSize*MinSize - 4,
+		},
+		{
+			name:  "more items than free tiles",
+			width: MinSize, height: MinSize,
+			items: MinSize*MinSize - 3,
+			want:  []Violation{{Field: "Items", Rule: "max"}},
+		},
+		{
+			name:  "walls leaving the bases alone",
+			width: 10, height: 10,
+			layout: layout(Point{1, 1}, Point{1, 8}),
+		},
+		{
+			name:  "a chest walled off",
+			width: 10, height: 10,
+			layout: layout(Point{5, 5}, Point{1, 1}),
+			want:   []Violation{{Field: "Layout.Inaccessible", Rule: "unreachable"}},
+		},
+		{
+			name:  "a spawn walled off",
+			width: 10, height: 10,
+			layout: layout(Point{1, 1}, Point{1, 8}, Point{5, 5}),
+			want:   []Violation{{Field: "Layout.Inaccessible", Rule: "unreachable"}},
+		},
+		{
+			name:  "a chest and a spawn walled off together",
+			width: 10, height: 10,
+			layout: Layout{
+				Chests:       map[Team.Enum]Point{Team.Team1: {5, 5}, Team.Team2: {8, 8}},
+				Spawns:       map[Team.Enum][]Point{Team.Team1: {{5, 6}}, Team.Team2: {{8, 1}}},
+				Inaccessible: []Point{{4, 4}, {5, 4}, {6, 4}, {4, 5}, {6, 5}, {4, 6}, {6, 6}, {4, 7}, {5, 7}, {6, 7}},
+			},
+		},
+		{
+			name:  "the unreachable listed with the other violations",
+			width: 10, height: 10,
+			items:  100,
+			layout: layout(Point{5, 5}, Point{1, 1}),
+			want:   []Violation{{Field: "Items", Rule: "max"}, {Field: "Layout.Inaccessible", Rule: "unreachable"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.width, tt.height, teams, tt.items, tt.layout)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var violations Violations
+			if !errors.As(err, &violations) {
+				t.Fatalf("Validate() error = %v, want Violations", err)
+			}
+			if !errors.Is(err, ErrInvalidMap) {
+				t.Fatalf("Validate() error = %v, want an ErrInvalidMap", err)
+			}
+
+			var got []Violation
+			for _, violation := range violations {
+				got = append(got, Violation{Field: violation.Field, Rule: violation.Rule})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Validate() = %v, want %v", violations, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package Movement
+
+import (
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Map"
+	"ChoHanJi/domain/TileFlag"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidPath is returned when a route skips a tile, leaves the map, enters
+// an inaccessible tile or is longer than the player can move in one turn.
+var ErrInvalidPath = Failure.New(Failure.Invalid, "invalid path")
+
+// Position is a tile of a route.
+type Position struct {
+	X int `json:"X" validate:"gte=0"`
+	Y int `json:"Y" validate:"gte=0"`
+}
+
+// Straight is the route used when a move gives no path: horizontally first,
+// then vertically.
+func Straight(from, to Position) []Position {
+	var route []Position
+
+	current := from
+	for current.X != to.X {
+		current.X += sign(to.X - current.X)
+		route = append(route, current)
+	}
+	for current.Y != to.Y {
+		current.Y += sign(to.Y - current.Y)
+		route = append(route, current)
+	}
+
+	return route
+}
+
+// Validate checks a route starting next to from. Each step goes to an
+// adjacent passable tile and the route holds at most speed steps. A negative
+// speed leaves the length unchecked.
+func Validate(m *Map.Map, from Position, route []Position, speed int) error {
+	if speed >= 0 && len(route) > speed {
+		return fmt.Errorf("Movement.Validate: %w: %d steps for a speed of %d", ErrInvalidPath, len(route), speed)
+	}
+
+	previous := from
+	for _, step := range route {
+		if abs(step.X-previous.X)+abs(step.Y-previous.Y) != 1 {
+			return fmt.Errorf("Movement.Validate: %w: %v is not next to %v", ErrInvalidPath, step, previous)
+		}
+		if !Passable(m, step) {
+			return fmt.Errorf("Movement.Validate: %w: %v cannot be entered", ErrInvalidPath, step)
+		}
+		previous = step
+	}
+
+	return nil
+}
+
+// Passable reports whether the tile exists and can be entered.
+func Passable(m *Map.Map, position Position) bool {
+	tile, err := m.GetTile(position.X, position.Y)
+	if err != nil {
+		return false
+	}
+	return tile.Flag != TileFlag.INACCESSIBLE
+}
+
+// Reachable lists the tiles reachable from the start in at most speed steps,
+// the start excluded, ordered by x then y. A tile where stops holds can be
+// entered but ends the movement. A nil stops never holds.
+func Reachable(m *Map.Map, from Position, speed int, stops func(Position) bool) []Position {
+	distances := map[Position]int{from: 0}
+	queue := []Position{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if distances[current] == speed || (current != from && stops != nil && stops(current)) {
+			continue
+		}
+
+		for _, next := range neighbours(current) {
+			if _, seen := distances[next]; seen || !Passable(m, next) {
+				continue
+			}
+			distances[next] = distances[current] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	reachable := make([]Position, 0, len(distances))
+	for position := range distances {
+		if position != from {
+			reachable = append(reachable, position)
+		}
+	}
+	slices.SortFunc(reachable, func(a, b Position) int {
+		if a.X != b.X {
+			return a.X - b.X
+		}
+		return a.Y - b.Y
+	})
+
+	return reachable
+}
+
+func neighbours(p Position) []Position {
+	return []Position{{p.X + 1, p.Y}, {p.X - 1, p.Y}, {p.X, p.Y + 1}, {p.X, p.Y - 1}}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package Player
+
+import (
+	"ChoHanJi/domain/Item"
+	"slices"
+)
+
+// IsFull reports whether the player carries as many items as their class allows.
+func (s *Struct) IsFull() bool {
+	return len(s.Inventory) >= s.Class.Capacity
+}
+
+// Carry puts the item in the inventory of the player.
+func (s *Struct) Carry(item *Item.Struct) {
+	s.Inventory = append(s.Inventory, item)
+}
+
+// Find returns the item the player carries, or nil.
+func (s *Struct) Find(id Item.Id) *Item.Struct {
+	index := slices.IndexFunc(s.Inventory, func(item *Item.Struct) bool { return item.Id == id })
+	if index < 0 {
+		return nil
+	}
+	return s.Inventory[index]
+}
+
+// Take removes the item from the inventory of the player and returns it, or nil.
+func (s *Struct) Take(id Item.Id) *Item.Struct {
+	index := slices.IndexFunc(s.Inventory, func(item *Item.Struct) bool { return item.Id == id })
+	if index < 0 {
+		return nil
+	}
+	item := s.Inventory[index]
+	s.Inventory = slices.Delete(s.Inventory, index, index+1)
+	return item
+}
+
+// TakeTreasures removes the treasures from the inventory of the player and returns them.
+func (s *Struct) TakeTreasures() []*Item.Struct {
+	var treasures []*Item.Struct
+	s.Inventory = slices.DeleteFunc(s.Inventory, func(item *Item.Struct) bool {
+		if item.IsConsumable() {
+			return false
+		}
+		treasures = append(treasures, item)
+		return true
+	})
+	return treasures
+}
+
+// InventoryIds returns the ids of the items the player carries.
+func (s *Struct) InventoryIds() []Item.Id {
+	ids := make([]Item.Id, 0, len(s.Inventory))
+	for _, item := range s.Inventory {
+		ids = append(ids, item.Id)
+	}
+	return ids
+}
+
+// Weight adds up the weight of the items the player carries.
+func (s *Struct) Weight() int {
+	weight := 0
+	for _, item := range s.Inventory {
+		weight += item.Weight
+	}
+	return weight
+}
@@ -4,12 +4,14 @@ import (
 	c "ChoHanJi/domain/Class"
 	"ChoHanJi/domain/IdGenerator"
 	"ChoHanJi/domain/Item"
-	"errors"
-	"strings"
 )
 
 type Id string
 
+// OffBoard is the coordinate of the players kept off the map, the dead
+// waiting to respawn and the late joiners waiting to spawn.
+const OffBoard = -1
+
 type Struct struct {
 	X          int `json:"X"`
 	Y          int `json:"Y"`
@@ -17,12 +19,14 @@ type Struct struct {
 	IdStr      string `json:"Id"`
 	Name       string `json:"Name"`
 	Class      c.Struct
-	ClassName  string       `json:"Class"`
-	Bag        *Item.Struct `json:"Item,omitempty"`
-	TeamNumber int          `json:"Team"`
+	ClassName  string         `json:"Class"`
+	Inventory  []*Item.Struct `json:"Inventory"`
+	TeamNumber int            `json:"Team"`
+	Status     Status         `json:"Status"`
 }
 
-func New(players map[Id]*Struct, name, class string, team int) (*Struct, error) {
+// New creates a player of the class, className being the name it was picked by.
+func New(players map[Id]*Struct, name, className string, class c.Struct, team int) (*Struct, error) {
 	var player *Struct
 	for {
 		strId, err := IdGenerator.NewId()
@@ -36,17 +40,13 @@ func New(players map[Id]*Struct, name, class string, team int) (*Struct, error)
 			continue
 		}
 
-		playerClass, err := getClass(strings.ToUpper(class))
-		if err != nil {
-			return nil, err
-		}
-
 		player = &Struct{
 			Id:         id,
 			IdStr:      string(id),
 			Name:       name,
-			Class:      playerClass,
-			ClassName:  class,
+			Class:      class,
+			ClassName:  className,
+			Inventory:  make([]*Item.Struct, 0, class.Capacity),
 			TeamNumber: team,
 		}
 
@@ -56,15 +56,11 @@ func New(players map[Id]*Struct, name, class string, team int) (*Struct, error)
 	return player, nil
 }
 
-func getClass(class string) (c.Struct, error) {
-	switch class {
-	case "FIGHTER":
-		return c.Fighter, nil
-	case "RANGER":
-		return c.Ranger, nil
-	case "THIEF":
-		return c.Rogue, nil
-	default:
-		return c.Struct{}, errors.New("class not defined")
+// Speed is how many tiles the player may move this turn.
+func (s *Struct) Speed() int {
+	speed := s.Class.MovementSpeed + s.Status.SpeedBonus
+	if weight := s.Weight(); weight > s.Class.CarryWeight {
+		speed -= weight - s.Class.CarryWeight
 	}
+	return max(speed, 1)
 }
@@ -0,0 +1,41 @@
+package Player
+
+import "ChoHanJi/domain/Item"
+
+// Status holds what the consumed items still do to the player.
+type Status struct {
+	// BonusHP is how many lost fights the player survives.
+	BonusHP int `json:"BonusHP"`
+	// SpeedBonus is added to the speed of the next move.
+	SpeedBonus int `json:"SpeedBonus"`
+	// RevealTurns is how many more turns the player sees through the fog.
+	RevealTurns int `json:"RevealTurns"`
+	// RespawnTurns is how many more turns the dead player sits out.
+	RespawnTurns int `json:"RespawnTurns"`
+	// Invulnerable keeps the player who just respawned from dying this turn.
+	Invulnerable bool `json:"Invulnerable"`
+}
+
+// IsRespawning reports whether the player is dead and waiting to respawn.
+func (s *Struct) IsRespawning() bool {
+	return s.Status.RespawnTurns > 0
+}
+
+// Consume applies the effect of the item to the player.
+func (s *Struct) Consume(item *Item.Struct) {
+	switch item.Effect {
+	case Item.Heal:
+		s.Status.BonusHP += item.Magnitude
+	case Item.SpeedBoost:
+		s.Status.SpeedBonus += item.Magnitude
+	case Item.Reveal:
+		s.Status.RevealTurns += item.Magnitude
+	}
+}
+
+// EndTurn wears off the effects lasting a number of turns.
+func (s *Struct) EndTurn() {
+	if s.Status.RevealTurns > 0 {
+		s.Status.RevealTurns--
+	}
+}
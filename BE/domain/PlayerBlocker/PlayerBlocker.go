@@ -1,16 +1,16 @@
 package PlayerBlocker
 
 import (
+	"ChoHanJi/domain/Failure"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/Room"
-	"errors"
 	"fmt"
 	"sync"
 )
 
 var (
-	ErrNotFound       = errors.New("not found")
-	ErrAlreadyBlocked = errors.New("the played is already blocked")
+	ErrNotFound       = Failure.New(Failure.NotFound, "not found")
+	ErrAlreadyBlocked = Failure.New(Failure.Conflict, "the played is already blocked")
 )
 
 type Struct struct {
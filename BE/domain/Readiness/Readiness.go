@@ -0,0 +1,64 @@
+package Readiness
+
+import (
+	"ChoHanJi/domain/Player"
+	"maps"
+	"slices"
+)
+
+// TeamStruct counts the ready players of a team.
+type TeamStruct struct {
+	Team  int `json:"Team"`
+	Ready int `json:"Ready"`
+	Total int `json:"Total"`
+}
+
+// Struct is the readiness of the players of a room for the current turn. A
+// player is ready once they submitted an action, skips included. The players
+// waiting to respawn, late joiners included, sit the turn out and are not
+// counted.
+type Struct struct {
+	Ready   []Player.Id  `json:"Ready"`
+	Teams   []TeamStruct `json:"Teams"`
+	players int
+}
+
+func New(players map[Player.Id]*Player.Struct, submitted []Player.Id) *Struct {
+	teams := make(map[int]*TeamStruct)
+	readiness := &Struct{Ready: make([]Player.Id, 0), Teams: make([]TeamStruct, 0), players: len(players)}
+
+	for _, player := range players {
+		if player.IsRespawning() {
+			continue
+		}
+
+		team, found := teams[player.TeamNumber]
+		if !found {
+			team = &TeamStruct{Team: player.TeamNumber}
+			teams[player.TeamNumber] = team
+		}
+		team.Total++
+
+		if slices.Contains(submitted, player.Id) {
+			team.Ready++
+			readiness.Ready = append(readiness.Ready, player.Id)
+		}
+	}
+
+	slices.Sort(readiness.Ready)
+	for _, number := range slices.Sorted(maps.Keys(teams)) {
+		readiness.Teams = append(readiness.Teams, *teams[number])
+	}
+
+	return readiness
+}
+
+// AllReady reports whether every player taking part in the turn is ready. An
+// empty room never is, a room where everyone waits to respawn always is.
+func (s *Struct) AllReady() bool {
+	total := 0
+	for _, team := range s.Teams {
+		total += team.Total
+	}
+	return s.players > 0 && len(s.Ready) == total
+}
@@ -5,12 +5,86 @@ import (
 	"ChoHanJi/domain/Item"
 	m "ChoHanJi/domain/Map"
 	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Team"
 )
 
 type Room struct {
-	Map     *m.Map
-	Players map[Player.Id]*Player.Struct
-	Items   map[Item.Id]*Item.Struct
+	Map      *m.Map
+	Players  map[Player.Id]*Player.Struct
+	Items    map[Item.Id]*Item.Struct
+	Settings Settings
+	// Penalties maps the teams to the points their deaths cost them.
+	Penalties map[int]int
+	// Started is set once the admin starts the game.
+	Started bool
+}
+
+// Settings are the rules chosen by the admin when the room is created.
+type Settings struct {
+	// FogOfWar limits what each player sees to the vision of their class.
+	FogOfWar bool
+	// PlanningSeconds is how long the players have to submit their actions
+	// before the turn proceeds on its own. Zero leaves it to the admin.
+	PlanningSeconds int
+	// RequireAllReady keeps the admin from proceeding until every player is
+	// ready. An expired planning timer proceeds regardless.
+	RequireAllReady bool
+	// AllowedClasses lists the classes the players may pick. Empty allows them all.
+	AllowedClasses []string
+	// ClassLimits caps how many players of a class each team may have.
+	ClassLimits map[string]int
+	// DeathDrop is what a dying player drops where they die.
+	DeathDrop DeathDrop
+	// ChestDefense makes a raider fight a defender standing by the chest first.
+	ChestDefense bool
+	// Scatter places the items on the map and scatters the raided chests.
+	Scatter m.Scatter
+	// Layout places the chests and the spawns of the teams and the inaccessible tiles.
+	Layout m.Layout
+	// Teams names and colors the teams playing, two to six of them.
+	Teams []Team.Struct
+	// FreeForAll gives every player a team of their own. The room seats one
+	// player per team, six at most, and turns the others away.
+	FreeForAll bool
+	// AutoAssign picks the team of the joining players instead of them.
+	AutoAssign AutoAssign
+	// TeamSize caps how many players a team may have. Zero leaves it open.
+	TeamSize int
+	// LateJoin tells what becomes of the players joining once the game started.
+	LateJoin LateJoin
+	// RespawnTurns is how many turns a dead player sits out before respawning.
+	RespawnTurns int
+	// SpawnInvulnerability keeps the respawned players from dying for a turn.
+	SpawnInvulnerability bool
+	// DeathPenalty is how many points a death costs the team of the player.
+	DeathPenalty int
+}
+
+// DeathDrop tells how many items a dying player drops.
+type DeathDrop string
+
+const (
+	// DropAll drops the whole inventory. It is the default.
+	DropAll DeathDrop = "All"
+	// DropOne drops the item picked up first and keeps the others.
+	DropOne DeathDrop = "One"
+)
+
+// LateJoin tells what becomes of the players joining a started game.
+type LateJoin string
+
+const (
+	// LateJoinRejected refuses them. It is the default.
+	LateJoinRejected LateJoin = ""
+	// LateJoinNextTurn spawns them once the turn being played is resolved.
+	LateJoinNextTurn LateJoin = "NextTurn"
+	// LateJoinSpectator lets them watch the game only.
+	LateJoinSpectator LateJoin = "Spectator"
+)
+
+// Score is what the team has on its treasure chest minus its penalties.
+func (r *Room) Score(team int) int {
+	return r.Map.Score(Team.Enum(team)) - r.Penalties[team]
 }
 
 type (
@@ -40,6 +114,7 @@ func CreateRoom(rooms Rooms, fieldMap *m.Map) (Id, error) {
 		room.Map = fieldMap
 		room.Players = make(map[Player.Id]*Player.Struct)
 		room.Items = make(map[Item.Id]*Item.Struct)
+		room.Penalties = make(map[int]int)
 
 		rooms[id] = room
 
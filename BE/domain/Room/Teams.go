@@ -0,0 +1,151 @@
+package Room
+
+import (
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Team"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownTeam       = Failure.New(Failure.Invalid, "the team does not play in this room")
+	ErrTeamFull          = Failure.New(Failure.Conflict, "the team is full")
+	ErrRoomFull          = Failure.New(Failure.Conflict, "every team of the room is full")
+	ErrClassLimitReached = Failure.New(Failure.Conflict, "the team already has as many players of the class as allowed")
+)
+
+// AutoAssign tells how the room picks the team of the joining players.
+type AutoAssign string
+
+const (
+	// AssignNone lets the players pick their team. It is the default.
+	AssignNone AutoAssign = ""
+	// AssignByCount puts the player in the team with the fewest players.
+	AssignByCount AutoAssign = "Count"
+	// AssignByClass puts the player in the team with the fewest players of
+	// their class, then with the fewest players.
+	AssignByClass AutoAssign = "Class"
+)
+
+// Roster is a team with the players in it.
+type Roster struct {
+	Team    Team.Struct    `json:"Team"`
+	Players []RosterPlayer `json:"Players"`
+}
+
+type RosterPlayer struct {
+	Id    Player.Id `json:"Id"`
+	Name  string    `json:"Name"`
+	Class string    `json:"Class"`
+}
+
+// TeamSize is how many players a team may have, zero when there is no cap.
+// In free for all, every player has a team of their own, so the room seats as
+// many players as it has teams.
+func (r *Room) TeamSize() int {
+	if r.Settings.FreeForAll {
+		return 1
+	}
+	return r.Settings.TeamSize
+}
+
+// CountPlayers counts the players of the team but the excluded one, only
+// those of the class unless className is empty.
+func (r *Room) CountPlayers(team int, className string, exclude Player.Id) int {
+	count := 0
+	for _, player := range r.Players {
+		if player.Id == exclude || player.TeamNumber != team {
+			continue
+		}
+		if className != "" && player.Class.Name != className {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// CanJoin checks a player of the class may join the team. A player already in
+// the room is excluded from the counts.
+func (r *Room) CanJoin(team int, className string, exclude Player.Id) error {
+	if !slices.Contains(r.Map.Teams(), Team.Enum(team)) {
+		return ErrUnknownTeam
+	}
+
+	if size := r.TeamSize(); size > 0 && r.CountPlayers(team, "", exclude) >= size {
+		return ErrTeamFull
+	}
+
+	if limit, found := r.Settings.ClassLimits[className]; found && r.CountPlayers(team, className, exclude) >= limit {
+		return ErrClassLimitReached
+	}
+
+	return nil
+}
+
+// PickTeam returns the team a joining player of the class plays for. The
+// players pick their team unless the room assigns them, free for all rooms
+// included, to the team they unbalance the least. The lowest team number
+// breaks the ties.
+func (r *Room) PickTeam(requested int, className string) (int, error) {
+	if !r.Settings.FreeForAll && r.Settings.AutoAssign == AssignNone {
+		if err := r.CanJoin(requested, className, ""); err != nil {
+			return 0, err
+		}
+		return requested, nil
+	}
+
+	picked, pickedLoad := 0, []int(nil)
+	for _, team := range r.Map.Teams() {
+		if r.CanJoin(int(team), className, "") != nil {
+			continue
+		}
+
+		load := []int{r.CountPlayers(int(team), "", "")}
+		if r.Settings.AutoAssign == AssignByClass {
+			load = append([]int{r.CountPlayers(int(team), className, "")}, load...)
+		}
+		if pickedLoad == nil || slices.Compare(load, pickedLoad) < 0 {
+			picked, pickedLoad = int(team), load
+		}
+	}
+
+	if pickedLoad == nil && r.Settings.FreeForAll {
+		return 0, fmt.Errorf("%w: a free-for-all room seats one player per team, %d of them", ErrRoomFull, len(r.Map.Teams()))
+	}
+	if pickedLoad == nil {
+		return 0, ErrRoomFull
+	}
+	return picked, nil
+}
+
+// Rosters lists the teams with their players, sorted by name.
+func (r *Room) Rosters() []Roster {
+	rosters := make([]Roster, len(r.Settings.Teams))
+	index := make(map[int]int, len(r.Settings.Teams))
+	for i, team := range r.Settings.Teams {
+		rosters[i] = Roster{Team: team, Players: []RosterPlayer{}}
+		index[int(team.Number)] = i
+	}
+
+	for _, player := range r.Players {
+		i, found := index[player.TeamNumber]
+		if !found {
+			continue
+		}
+		rosters[i].Players = append(rosters[i].Players, RosterPlayer{player.Id, player.Name, player.Class.Name})
+	}
+
+	for _, roster := range rosters {
+		slices.SortFunc(roster.Players, func(a, b RosterPlayer) int {
+			if c := strings.Compare(a.Name, b.Name); c != 0 {
+				return c
+			}
+			return strings.Compare(string(a.Id), string(b.Id))
+		})
+	}
+
+	return rosters
+}
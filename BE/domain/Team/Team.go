@@ -1,5 +1,10 @@
 package Team
 
+import (
+	"ChoHanJi/domain/Failure"
+	"fmt"
+)
+
 type Enum int
 
 const (
@@ -7,3 +12,55 @@ const (
 	Team1
 	Team2
 )
+
+const (
+	// MinTeams is the fewest teams a room plays with.
+	MinTeams = 2
+	// MaxTeams is the most teams a room plays with.
+	MaxTeams = 6
+)
+
+var ErrInvalidTeams = Failure.New(Failure.Invalid, "invalid teams")
+
+// Struct is a team as the admin named and colored it.
+type Struct struct {
+	Number Enum   `json:"Number"`
+	Name   string `json:"Name"`
+	// Color is a CSS hex color such as #1d4ed8.
+	Color string `json:"Color"`
+}
+
+var defaultColors = [MaxTeams]string{"#1d4ed8", "#b91c1c", "#15803d", "#a16207", "#7e22ce", "#0e7490"}
+
+// Roster numbers the teams from one in order and names and colors those the
+// admin left blank. No team stands for the two default teams.
+func Roster(teams []Struct) ([]Struct, error) {
+	if len(teams) == 0 {
+		teams = make([]Struct, MinTeams)
+	}
+	if len(teams) < MinTeams || len(teams) > MaxTeams {
+		return nil, fmt.Errorf("%w: rooms play with %d to %d teams, not %d", ErrInvalidTeams, MinTeams, MaxTeams, len(teams))
+	}
+
+	roster := make([]Struct, len(teams))
+	for i, team := range teams {
+		team.Number = Enum(i + 1)
+		if team.Name == "" {
+			team.Name = fmt.Sprintf("Team %d", team.Number)
+		}
+		if team.Color == "" {
+			team.Color = defaultColors[i]
+		}
+		roster[i] = team
+	}
+	return roster, nil
+}
+
+// Numbers lists the numbers of the teams.
+func Numbers(teams []Struct) []Enum {
+	numbers := make([]Enum, len(teams))
+	for i, team := range teams {
+		numbers[i] = team.Number
+	}
+	return numbers
+}
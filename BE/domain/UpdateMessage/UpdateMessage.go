@@ -8,22 +8,29 @@ import (
 type Struct struct {
 	PlayerChanges map[Player.Id]*PlayerChange `json:"PlayerChanges"`
 	ItemChanges   map[Item.Id]*ItemChange     `json:"ItemChanges"`
+	// Scores maps the teams to the points on their treasure chest.
+	Scores map[int]int `json:"Scores,omitempty"`
 }
 
 func New() *Struct {
 	return &Struct{
-		make(map[Player.Id]*PlayerChange),
-		make(map[Item.Id]*ItemChange),
+		PlayerChanges: make(map[Player.Id]*PlayerChange),
+		ItemChanges:   make(map[Item.Id]*ItemChange),
 	}
 }
 
 type PlayerChange struct {
-	X      int
-	Y      int
-	PrevX  int
-	PrevY  int
-	Id     Player.Id
-	ItemId *Item.Id
+	X     int
+	Y     int
+	PrevX int
+	PrevY int
+	Id    Player.Id
+	// Inventory lists the items the player carries after the change.
+	Inventory []Item.Id
+	// InterruptedBy is the enemy whose zone of control stopped the move at X, Y.
+	InterruptedBy Player.Id `json:"InterruptedBy,omitempty"`
+	// Effects lists the effects of the items the player consumed.
+	Effects []Item.Effect `json:"Effects,omitempty"`
 }
 
 type ItemChange struct {
@@ -34,13 +41,13 @@ type ItemChange struct {
 	ItemId Item.Id
 }
 
-func (s *Struct) UpsertPlayer(id Player.Id, X, Y, PrevX, PrevY int, itemId *Item.Id) {
+func (s *Struct) UpsertPlayer(id Player.Id, X, Y, PrevX, PrevY int, inventory []Item.Id) {
 	if player, found := s.PlayerChanges[id]; !found {
-		s.PlayerChanges[id] = &PlayerChange{X, Y, PrevX, PrevY, id, itemId}
+		s.PlayerChanges[id] = &PlayerChange{X: X, Y: Y, PrevX: PrevX, PrevY: PrevY, Id: id, Inventory: inventory}
 	} else {
 		player.X = X
 		player.Y = Y
-		player.ItemId = itemId
+		player.Inventory = inventory
 	}
 }
 
@@ -52,3 +59,17 @@ func (s *Struct) UpsertItem(id Item.Id, X, Y, PrevX, PrevY int) {
 		item.Y = Y
 	}
 }
+
+// InterruptPlayer records the enemy who stopped the move of the player.
+func (s *Struct) InterruptPlayer(id Player.Id, by Player.Id) {
+	if player, found := s.PlayerChanges[id]; found {
+		player.InterruptedBy = by
+	}
+}
+
+// AddEffect records the effect of an item the player consumed.
+func (s *Struct) AddEffect(id Player.Id, effect Item.Effect) {
+	if player, found := s.PlayerChanges[id]; found {
+		player.Effects = append(player.Effects, effect)
+	}
+}
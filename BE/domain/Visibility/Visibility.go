@@ -0,0 +1,198 @@
+package Visibility
+
+import (
+	"ChoHanJi/domain/Item"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Team"
+	"ChoHanJi/domain/UpdateMessage"
+	"math"
+	"slices"
+)
+
+// Hidden is the coordinate sent in place of whatever the viewer cannot see,
+// the one of the players off the board.
+const Hidden = Player.OffBoard
+
+type position struct {
+	X int
+	Y int
+}
+
+// CanSee reports whether the viewer sees the tile at x, y from where it stands.
+func CanSee(viewer *Player.Struct, x, y int) bool {
+	return canSee(position{viewer.X, viewer.Y}, vision(viewer), position{x, y})
+}
+
+// vision is how far the viewer sees, everywhere while an item reveals the map.
+func vision(viewer *Player.Struct) int {
+	if viewer.Status.RevealTurns > 0 {
+		return math.MaxInt
+	}
+	return viewer.Class.Vision
+}
+
+// Vision reaches the same number of tiles in every direction, diagonals
+// included. Nothing is seen from off the board, where the dead wait.
+func canSee(from position, vision int, to position) bool {
+	if from.X < 0 || from.Y < 0 || to.X < 0 || to.Y < 0 {
+		return false
+	}
+	return abs(from.X-to.X) <= vision && abs(from.Y-to.Y) <= vision
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// SeesPlayer reports whether the viewer sees the player. Teammates always see
+// each other.
+func SeesPlayer(viewer, player *Player.Struct) bool {
+	return player.TeamNumber == viewer.TeamNumber || CanSee(viewer, player.X, player.Y)
+}
+
+// Witnesses lists the players seeing what happens on the tile at x, y: the
+// ones involved and the ones in sight of it.
+func Witnesses(players map[Player.Id]*Player.Struct, x, y int, involved ...Player.Id) []Player.Id {
+	var witnesses []Player.Id
+	for id, player := range players {
+		if slices.Contains(involved, id) || CanSee(player, x, y) {
+			witnesses = append(witnesses, id)
+		}
+	}
+	slices.Sort(witnesses)
+	return witnesses
+}
+
+// FilterPlayers returns copies of the players where the enemies out of sight
+// are hidden along with their inventory. Teammates always see each other.
+func FilterPlayers(viewer *Player.Struct, players []*Player.Struct) []*Player.Struct {
+	filtered := make([]*Player.Struct, 0, len(players))
+	for _, player := range players {
+		copied := *player
+		if !SeesPlayer(viewer, player) {
+			copied.X, copied.Y = Hidden, Hidden
+			copied.Inventory = nil
+		}
+		filtered = append(filtered, &copied)
+	}
+	return filtered
+}
+
+// FilterItems returns copies of the items where the ones out of sight are hidden.
+func FilterItems(viewer *Player.Struct, items []*Item.Struct) []*Item.Struct {
+	filtered := make([]*Item.Struct, 0, len(items))
+	for _, item := range items {
+		copied := *item
+		if !CanSee(viewer, item.X, item.Y) {
+			copied.X, copied.Y = Hidden, Hidden
+		}
+		filtered = append(filtered, &copied)
+	}
+	return filtered
+}
+
+// Neutral is a viewer of no team standing off the board. It sees neither the
+// players nor the items, as a spectator of a fogged game.
+func Neutral() *Player.Struct {
+	return &Player.Struct{TeamNumber: int(Team.NEUTRAL), X: Hidden, Y: Hidden}
+}
+
+// FilterUpdate builds the update the player is allowed to see. Visibility is
+// evaluated both before and after the turn, so whatever leaves the sight of
+// the viewer is moved to Hidden and whatever enters it appears from Hidden,
+// including the objects that stood still while the viewer moved.
+func FilterUpdate(
+	viewerId Player.Id,
+	players map[Player.Id]*Player.Struct,
+	items map[Item.Id]*Item.Struct,
+	changes *UpdateMessage.Struct,
+) *UpdateMessage.Struct {
+	viewer, found := players[viewerId]
+	if !found {
+		return UpdateMessage.New()
+	}
+	return FilterUpdateFor(viewer, players, items, changes)
+}
+
+// FilterUpdateFor builds the update the viewer is allowed to see, whether it
+// plays or not.
+func FilterUpdateFor(
+	viewer *Player.Struct,
+	players map[Player.Id]*Player.Struct,
+	items map[Item.Id]*Item.Struct,
+	changes *UpdateMessage.Struct,
+) *UpdateMessage.Struct {
+	reach := vision(viewer)
+	viewerBefore, viewerAfter := playerPositions(viewer, changes)
+
+	filtered := UpdateMessage.New()
+	filtered.Scores = changes.Scores
+
+	for id, player := range players {
+		change, changed := changes.PlayerChanges[id]
+
+		if player.TeamNumber == viewer.TeamNumber {
+			if changed {
+				copied := *change
+				filtered.PlayerChanges[id] = &copied
+			}
+			continue
+		}
+
+		before, after := playerPositions(player, changes)
+		seenBefore := canSee(viewerBefore, reach, before)
+		seenAfter := canSee(viewerAfter, reach, after)
+
+		switch {
+		case seenAfter && (changed || !seenBefore):
+			prev := hideUnless(seenBefore, before)
+			filtered.UpsertPlayer(id, after.X, after.Y, prev.X, prev.Y, player.InventoryIds())
+		case seenBefore && !seenAfter:
+			filtered.UpsertPlayer(id, Hidden, Hidden, before.X, before.Y, nil)
+		}
+	}
+
+	for id, item := range items {
+		_, changed := changes.ItemChanges[id]
+
+		before, after := itemPositions(item, changes)
+		seenBefore := canSee(viewerBefore, reach, before)
+		seenAfter := canSee(viewerAfter, reach, after)
+
+		switch {
+		case seenAfter && (changed || !seenBefore):
+			prev := hideUnless(seenBefore, before)
+			filtered.UpsertItem(id, after.X, after.Y, prev.X, prev.Y)
+		case seenBefore && !seenAfter:
+			filtered.UpsertItem(id, Hidden, Hidden, before.X, before.Y)
+		}
+	}
+
+	return filtered
+}
+
+func hideUnless(seen bool, p position) position {
+	if !seen {
+		return position{Hidden, Hidden}
+	}
+	return p
+}
+
+func playerPositions(player *Player.Struct, changes *UpdateMessage.Struct) (position, position) {
+	change, found := changes.PlayerChanges[player.Id]
+	if !found {
+		return position{player.X, player.Y}, position{player.X, player.Y}
+	}
+	return position{change.PrevX, change.PrevY}, position{change.X, change.Y}
+}
+
+func itemPositions(item *Item.Struct, changes *UpdateMessage.Struct) (position, position) {
+	change, found := changes.ItemChanges[item.Id]
+	if !found {
+		return position{item.X, item.Y}, position{item.X, item.Y}
+	}
+	return position{change.PrevX, change.PrevY}, position{change.X, change.Y}
+}
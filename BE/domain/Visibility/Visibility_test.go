@@ -0,0 +1,54 @@
+package Visibility
+
+import (
+	"ChoHanJi/domain/Class"
+	"ChoHanJi/domain/Player"
+	"slices"
+	"testing"
+)
+
+func player(id Player.Id, team, x, y int) *Player.Struct {
+	return &Player.Struct{Id: id, TeamNumber: team, X: x, Y: y, Class: Class.Struct{Vision: 2}}
+}
+
+func TestCanSee(t *testing.T) {
+	tests := []struct {
+		name   string
+		viewer *Player.Struct
+		x, y   int
+		want   bool
+	}{
+		{"in sight", player("a", 1, 5, 5), 7, 3, true},
+		{"out of sight", player("a", 1, 5, 5), 8, 5, false},
+		{"off the board", player("a", 1, 0, 0), Hidden, Hidden, false},
+		{"from off the board", player("a", 1, Hidden, Hidden), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanSee(tt.viewer, tt.x, tt.y); got != tt.want {
+				t.Fatalf("CanSee(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWitnesses(t *testing.T) {
+	players := map[Player.Id]*Player.Struct{
+		"near":     player("near", 1, 4, 4),
+		"far":      player("far", 1, 9, 9),
+		"fighter":  player("fighter", 2, 9, 0),
+		"teammate": player("teammate", 2, 0, 9),
+		"dead":     player("dead", 2, Hidden, Hidden),
+	}
+
+	got := Witnesses(players, 5, 5, "fighter")
+	want := []Player.Id{"fighter", "near"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Witnesses() = %v, want %v", got, want)
+	}
+
+	if got := Witnesses(players, 0, 0); len(got) != 0 {
+		t.Fatalf("Witnesses() of the corner = %v, want nobody", got)
+	}
+}
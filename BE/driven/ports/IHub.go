@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"ChoHanJi/domain/Event"
+	"strings"
+)
+
+// SpectatorPrefix marks the subscribers that only watch the room.
+const SpectatorPrefix = "spectator:"
+
+// HubInterface fans messages out to the subscribers of a room.
+type HubInterface interface {
+	Subscribe(roomId, subscriberId string) <-chan []byte
+	Unsubscribe(roomId, subscriberId string) error
+	Publish(roomId, subscriberId string, event Event.Interface) error
+	PublishToAll(roomId string, event Event.Interface) error
+	PublishToSpectators(roomId string, event Event.Interface) error
+}
+
+// SpectatorId returns the subscriber id of a spectator.
+func SpectatorId(id string) string {
+	return SpectatorPrefix + id
+}
+
+// IsSpectator reports whether the subscriber only watches the room.
+func IsSpectator(subscriberId string) bool {
+	return strings.HasPrefix(subscriberId, SpectatorPrefix)
+}
@@ -0,0 +1,148 @@
+package RedisHub
+
+import (
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/driven/ports"
+	"ChoHanJi/driven/sse/SSEHub"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	channelPrefix  = "ChoHanJi"
+	publishTimeout = 5 * time.Second
+)
+
+// envelope is what travels over Redis. An empty SubscriberId addresses every
+// subscriber of the room, unless Spectators restricts it to the spectators.
+type envelope struct {
+	SubscriberId string          `json:"SubscriberId,omitempty"`
+	Spectators   bool            `json:"Spectators,omitempty"`
+	Message      json.RawMessage `json:"Message"`
+}
+
+// Struct relays messages through Redis pub/sub so that every backend instance
+// delivers them to the subscribers connected to it. Delivery to the local
+// subscribers is delegated to an in-memory SSEHub.
+type Struct struct {
+	client    redis.UniversalClient
+	namespace string
+	local     *SSEHub.Struct
+	pubsub    *redis.PubSub
+}
+
+var _ ports.HubInterface = (*Struct)(nil)
+
+// New subscribes to every room of the namespace. Hubs sharing a namespace
+// share messages, so the lobby and game hubs must use different namespaces.
+func New(ctx context.Context, client redis.UniversalClient, namespace string) (*Struct, error) {
+	pattern := channel(namespace, "*")
+
+	pubsub := client.PSubscribe(ctx, pattern)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("RedisHub.New: Could not subscribe to %s: %w", pattern, err)
+	}
+
+	hub := &Struct{
+		client:    client,
+		namespace: namespace,
+		local:     SSEHub.New(),
+		pubsub:    pubsub,
+	}
+
+	go hub.listen(pubsub.Channel())
+
+	return hub, nil
+}
+
+func (h *Struct) Subscribe(roomId, subscriberId string) <-chan []byte {
+	return h.local.Subscribe(roomId, subscriberId)
+}
+
+func (h *Struct) Unsubscribe(roomId, subscriberId string) error {
+	return h.local.Unsubscribe(roomId, subscriberId)
+}
+
+func (h *Struct) Publish(roomId, subscriberId string, event Event.Interface) error {
+	if err := h.send(roomId, envelope{SubscriberId: subscriberId}, event); err != nil {
+		return fmt.Errorf("RedisHub.Publish: %w", err)
+	}
+	return nil
+}
+
+func (h *Struct) PublishToAll(roomId string, event Event.Interface) error {
+	if err := h.send(roomId, envelope{}, event); err != nil {
+		return fmt.Errorf("RedisHub.PublishToAll: %w", err)
+	}
+	return nil
+}
+
+func (h *Struct) PublishToSpectators(roomId string, event Event.Interface) error {
+	if err := h.send(roomId, envelope{Spectators: true}, event); err != nil {
+		return fmt.Errorf("RedisHub.PublishToSpectators: %w", err)
+	}
+	return nil
+}
+
+// Close stops relaying messages from Redis.
+func (h *Struct) Close() error {
+	return h.pubsub.Close()
+}
+
+func (h *Struct) send(roomId string, message envelope, event Event.Interface) error {
+	var err error
+	message.Message, err = Event.Encode(event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("Could not marshal the message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	return h.client.Publish(ctx, channel(h.namespace, roomId), payload).Err()
+}
+
+func (h *Struct) listen(messages <-chan *redis.Message) {
+	prefix := channel(h.namespace, "")
+
+	for msg := range messages {
+		roomId := strings.TrimPrefix(msg.Channel, prefix)
+
+		var message envelope
+		if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+			slog.Default().Error("RedisHub.listen: Could not unmarshal the message", slog.Any("Error", err), slog.String("Channel", msg.Channel))
+			continue
+		}
+
+		// The addressed subscriber may be connected to another instance, so
+		// missing rooms and subscribers are expected here.
+		var err error
+		switch {
+		case message.Spectators:
+			err = h.local.PublishRawToSpectators(roomId, message.Message)
+		case message.SubscriberId == "":
+			err = h.local.PublishRawToAll(roomId, message.Message)
+		default:
+			err = h.local.PublishRaw(roomId, message.SubscriberId, message.Message)
+		}
+		if err != nil {
+			slog.Default().Debug("RedisHub.listen: Message not delivered locally", slog.Any("Error", err), slog.String("Channel", msg.Channel))
+		}
+	}
+}
+
+func channel(namespace, roomId string) string {
+	return fmt.Sprintf("%s:%s:%s", channelPrefix, namespace, roomId)
+}
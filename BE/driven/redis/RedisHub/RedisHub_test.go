@@ -0,0 +1,141 @@
+package RedisHub
+
+import (
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/driven/ports"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const roomId = "room1"
+
+// newHub starts a hub standing for a backend instance of its own, with its own
+// connection to the shared Redis.
+func newHub(t *testing.T, server *miniredis.Miniredis, namespace string) *Struct {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	hub, err := New(context.Background(), client, namespace)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = hub.Close() })
+
+	return hub
+}
+
+func encode(t *testing.T, event Event.Interface) string {
+	t.Helper()
+
+	msg, err := Event.Encode(event)
+	if err != nil {
+		t.Fatalf("Event.Encode() error = %v", err)
+	}
+	return string(msg)
+}
+
+func receive(t *testing.T, name string, ch <-chan []byte, want string) {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		if string(msg) != want {
+			t.Fatalf("%s received %s, want %s", name, msg, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("%s received nothing, want %s", name, want)
+	}
+}
+
+func receiveNothing(t *testing.T, name string, ch <-chan []byte) {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		t.Fatalf("%s received %s, want nothing", name, msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRelaysBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	sender := newHub(t, server, "game")
+	receiver := newHub(t, server, "game")
+	lobby := newHub(t, server, "lobby")
+
+	first := receiver.Subscribe(roomId, "p0001")
+	second := receiver.Subscribe(roomId, "p0002")
+	spectator := receiver.Subscribe(roomId, ports.SpectatorId("s0001"))
+	otherRoom := receiver.Subscribe("room2", "p0003")
+	otherNamespace := lobby.Subscribe(roomId, "p0001")
+
+	t.Run("Publish", func(t *testing.T) {
+		event := Event.PlayerLeftStruct{PlayerId: "p0001"}
+		if err := sender.Publish(roomId, "p0001", event); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+
+		receive(t, "the subscriber", first, encode(t, event))
+		receiveNothing(t, "another player", second)
+		receiveNothing(t, "a spectator", spectator)
+		receiveNothing(t, "another room", otherRoom)
+		receiveNothing(t, "another namespace", otherNamespace)
+	})
+
+	t.Run("PublishToAll", func(t *testing.T) {
+		event := Event.PlayerLeftStruct{PlayerId: "p0002"}
+		if err := sender.PublishToAll(roomId, event); err != nil {
+			t.Fatalf("PublishToAll() error = %v", err)
+		}
+
+		receive(t, "a player", first, encode(t, event))
+		receive(t, "another player", second, encode(t, event))
+		receive(t, "a spectator", spectator, encode(t, event))
+		receiveNothing(t, "another room", otherRoom)
+		receiveNothing(t, "another namespace", otherNamespace)
+	})
+
+	t.Run("PublishToSpectators", func(t *testing.T) {
+		event := Event.PlayerLeftStruct{PlayerId: "p0003"}
+		if err := sender.PublishToSpectators(roomId, event); err != nil {
+			t.Fatalf("PublishToSpectators() error = %v", err)
+		}
+
+		receive(t, "a spectator", spectator, encode(t, event))
+		receiveNothing(t, "a player", first)
+		receiveNothing(t, "another player", second)
+		receiveNothing(t, "another room", otherRoom)
+		receiveNothing(t, "another namespace", otherNamespace)
+	})
+}
+
+func TestNamespacesAreIsolated(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	game := newHub(t, server, "game")
+	lobby := newHub(t, server, "lobby")
+
+	inGame := game.Subscribe(roomId, "p0001")
+	inLobby := lobby.Subscribe(roomId, "p0001")
+
+	gameEvent := Event.PlayerLeftStruct{PlayerId: "p0001"}
+	if err := game.PublishToAll(roomId, gameEvent); err != nil {
+		t.Fatalf("PublishToAll() error = %v", err)
+	}
+	lobbyEvent := Event.PlayerRenamedStruct{PlayerId: "p0001", Name: "renamed"}
+	if err := lobby.PublishToAll(roomId, lobbyEvent); err != nil {
+		t.Fatalf("PublishToAll() error = %v", err)
+	}
+
+	receive(t, "the game subscriber", inGame, encode(t, gameEvent))
+	receive(t, "the lobby subscriber", inLobby, encode(t, lobbyEvent))
+	receiveNothing(t, "the game subscriber", inGame)
+	receiveNothing(t, "the lobby subscriber", inLobby)
+}
@@ -1,22 +1,20 @@
 package SSEHub
 
 import (
-	"encoding/json"
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/driven/ports"
 	"errors"
 	"fmt"
 	"sync"
 )
 
-type Message struct {
-	MessageType string `json:"MessageType"`
-	Message     string `json:"Message"`
-}
-
 type Struct struct {
 	mu      sync.RWMutex
 	clients map[string]map[string]chan []byte
 }
 
+var _ ports.HubInterface = (*Struct)(nil)
+
 func New() *Struct {
 	return &Struct{
 		clients: make(map[string]map[string]chan []byte),
@@ -68,14 +66,35 @@ func (h *Struct) Unsubscribe(roomId, subscriberId string) error {
 	return nil
 }
 
-func (h *Struct) Publish(roomId, subscriberId, messageType, messageBody string) error {
-	message := Message{messageType, messageBody}
-
-	msg, err := json.Marshal(message)
+func (h *Struct) Publish(roomId, subscriberId string, event Event.Interface) error {
+	msg, err := Event.Encode(event)
 	if err != nil {
 		return fmt.Errorf("SSEHub.Publish: Could not marshal the message: %w", err)
 	}
 
+	return h.PublishRaw(roomId, subscriberId, msg)
+}
+
+func (h *Struct) PublishToAll(roomId string, event Event.Interface) error {
+	msg, err := Event.Encode(event)
+	if err != nil {
+		return fmt.Errorf("SSEHub.PublishToAll: Could not marshal the message: %w", err)
+	}
+
+	return h.PublishRawToAll(roomId, msg)
+}
+
+func (h *Struct) PublishToSpectators(roomId string, event Event.Interface) error {
+	msg, err := Event.Encode(event)
+	if err != nil {
+		return fmt.Errorf("SSEHub.PublishToSpectators: Could not marshal the message: %w", err)
+	}
+
+	return h.PublishRawToSpectators(roomId, msg)
+}
+
+// PublishRaw delivers an already encoded message to a single subscriber.
+func (h *Struct) PublishRaw(roomId, subscriberId string, msg []byte) error {
 	h.mu.RLock()
 	clients, found := h.clients[roomId]
 	if !found {
@@ -99,14 +118,8 @@ func (h *Struct) Publish(roomId, subscriberId, messageType, messageBody string)
 	return nil
 }
 
-func (h *Struct) PublishToAll(roomId, messageType string, messageBody string) error {
-	message := Message{messageType, messageBody}
-
-	msg, err := json.Marshal(message)
-	if err != nil {
-		return fmt.Errorf("SSEHub.Publish: Could not marshal the message: %w", err)
-	}
-
+// PublishRawToAll delivers an already encoded message to every subscriber of the room.
+func (h *Struct) PublishRawToAll(roomId string, msg []byte) error {
 	h.mu.RLock()
 	clients, ok := h.clients[roomId]
 	if !ok {
@@ -134,3 +147,25 @@ func (h *Struct) PublishToAll(roomId, messageType string, messageBody string) er
 
 	return nil
 }
+
+// PublishRawToSpectators delivers an already encoded message to every spectator
+// of the room. Rooms without spectators are not an error.
+func (h *Struct) PublishRawToSpectators(roomId string, msg []byte) error {
+	h.mu.RLock()
+	var chans []chan []byte
+	for subscriberId, ch := range h.clients[roomId] {
+		if ports.IsSpectator(subscriberId) {
+			chans = append(chans, ch)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, ch := range chans {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+
+	return nil
+}
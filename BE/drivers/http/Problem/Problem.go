@@ -0,0 +1,179 @@
+package Problem
+
+import (
+	m "ChoHanJi/domain/Map"
+	"ChoHanJi/infrastructure/Logging"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// The types of problem the clients may tell apart. The others are about:blank,
+// their status saying it all.
+const (
+	TypeBlank         = "about:blank"
+	TypeMalformedBody = "urn:chohanji:problem:malformed-body"
+	TypeValidation    = "urn:chohanji:problem:validation"
+	TypeInvalidMap    = "urn:chohanji:problem:invalid-map"
+)
+
+// ErrNotStreamable is the error of the event streams the response writer
+// cannot flush.
+var ErrNotStreamable = errors.New("the response cannot be streamed")
+
+// Details is the body of every error response, after RFC 9457.
+type Details struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail explains this occurrence of the problem. The server errors keep
+	// theirs to the logs.
+	Detail string `json:"detail,omitempty"`
+	// Errors lists the fields of the request breaking a rule.
+	Errors []FieldError `json:"errors,omitempty"`
+	// RequestId finds the logs of the request.
+	RequestId string `json:"requestId,omitempty"`
+}
+
+// FieldError is a rule a field of the request breaks.
+type FieldError struct {
+	Field  string `json:"field"`
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+// Send logs the error with the message and answers the problem it is. The
+// status is the one of the sentinel error err wraps, status otherwise.
+func Send(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, status int, msg string, err error) {
+	logger.ErrorContext(ctx, msg, slog.Any("Error", err))
+
+	details := describe(status, err)
+	if details.Status >= http.StatusInternalServerError {
+		details.Detail = msg
+	}
+	write(ctx, w, logger, details)
+}
+
+// Internal answers a 500 for the error the caller has already logged.
+func Internal(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, msg string) {
+	write(ctx, w, logger, Details{
+		Type:   TypeBlank,
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+		Detail: msg,
+	})
+}
+
+// MissingParameter answers a 400 for the query parameter the request lacks.
+func MissingParameter(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, name string) {
+	logger.ErrorContext(ctx, "Missing query parameter", slog.String("Parameter", name))
+
+	write(ctx, w, logger, Details{
+		Type:   TypeValidation,
+		Title:  "Invalid request",
+		Status: http.StatusBadRequest,
+		Detail: fmt.Sprintf("the %s query parameter is required", name),
+		Errors: []FieldError{{Field: name, Rule: "required", Detail: fmt.Sprintf("%s is required", name)}},
+	})
+}
+
+// InvalidParameter answers a 400 for the query parameter the request sends
+// with a value out of its rule.
+func InvalidParameter(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, name, rule, detail string) {
+	logger.ErrorContext(ctx, "Invalid query parameter", slog.String("Parameter", name), slog.String("Detail", detail))
+
+	write(ctx, w, logger, Details{
+		Type:   TypeValidation,
+		Title:  "Invalid request",
+		Status: http.StatusBadRequest,
+		Detail: detail,
+		Errors: []FieldError{{Field: name, Rule: rule, Detail: detail}},
+	})
+}
+
+func describe(status int, err error) Details {
+	var (
+		syntaxErr     *json.SyntaxError
+		typeErr       *json.UnmarshalTypeError
+		validationErr validator.ValidationErrors
+		violations    m.Violations
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return Details{Type: TypeMalformedBody, Title: "Malformed request body", Status: http.StatusBadRequest, Detail: err.Error()}
+	case errors.As(err, &validationErr):
+		return Details{
+			Type:   TypeValidation,
+			Title:  "Invalid request",
+			Status: http.StatusBadRequest,
+			Detail: fmt.Sprintf("the request breaks %d rules", len(validationErr)),
+			Errors: fieldErrors(validationErr),
+		}
+	case errors.As(err, &violations):
+		errs := make([]FieldError, len(violations))
+		for i, violation := range violations {
+			errs[i] = FieldError{violation.Field, violation.Rule, violation.Detail}
+		}
+		return Details{
+			Type:   TypeInvalidMap,
+			Title:  "Invalid map",
+			Status: http.StatusBadRequest,
+			Detail: fmt.Sprintf("the map breaks %d rules", len(violations)),
+			Errors: errs,
+		}
+	}
+
+	if mapped, found := StatusOf(err); found {
+		status = mapped
+	}
+	return Details{Type: TypeBlank, Title: http.StatusText(status), Status: status, Detail: err.Error()}
+}
+
+// fieldErrors names the fields the way the clients send them, without the
+// name of the request struct.
+func fieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		field := fe.Namespace()
+		if _, rest, found := strings.Cut(field, "."); found {
+			field = rest
+		}
+
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+
+		fields[i] = FieldError{field, rule, fmt.Sprintf("%s breaks the %s rule", field, rule)}
+	}
+	return fields
+}
+
+func write(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, details Details) {
+	if requestId, err := Logging.GetRequestId(ctx); err == nil {
+		details.RequestId = requestId
+	}
+
+	body, err := json.Marshal(details)
+	if err != nil {
+		logger.ErrorContext(ctx, "Problem.write: Could not marshal the problem", slog.Any("Error", err))
+		w.WriteHeader(details.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	if _, err := w.Write(body); err != nil {
+		logger.ErrorContext(ctx, "Problem.write: Could not write the problem", slog.Any("Error", err))
+	}
+}
@@ -0,0 +1,32 @@
+package Problem
+
+import (
+	"ChoHanJi/domain/Failure"
+	"errors"
+	"net/http"
+)
+
+// statuses maps the kinds of failure to the status they answer. An error
+// carrying several kinds answers the status of the first one listed.
+var statuses = []struct {
+	kind   Failure.Kind
+	status int
+}{
+	// The room, the player or the fight the request names does not exist.
+	{Failure.NotFound, http.StatusNotFound},
+	// The request is fine but the game is not in a state to take it.
+	{Failure.Conflict, http.StatusConflict},
+	{Failure.Forbidden, http.StatusForbidden},
+	// The request itself is wrong.
+	{Failure.Invalid, http.StatusBadRequest},
+}
+
+// StatusOf returns the status of the kind of failure err carries, if any.
+func StatusOf(err error) (int, bool) {
+	for _, s := range statuses {
+		if errors.Is(err, s.kind) {
+			return s.status, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,39 @@
+package Problem
+
+import (
+	"ChoHanJi/domain/Failure"
+	m "ChoHanJi/domain/Map"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestStatusOf(t *testing.T) {
+	missing := Failure.New(Failure.NotFound, "not found")
+	busy := Failure.New(Failure.Conflict, "busy")
+	wrong := Failure.New(Failure.Invalid, "wrong")
+
+	tests := []struct {
+		name   string
+		err    error
+		want   int
+		mapped bool
+	}{
+		{"a sentinel", missing, http.StatusNotFound, true},
+		{"a wrapped sentinel", fmt.Errorf("Pkg.Func: %s %w", "room", busy), http.StatusConflict, true},
+		{"the first kind listed wins", fmt.Errorf("%w: %w", wrong, missing), http.StatusNotFound, true},
+		{"whatever the wrapping order", fmt.Errorf("%w: %w", busy, wrong), http.StatusConflict, true},
+		{"the map violations", m.Violations{{Field: "MapWidth", Rule: "min"}}, http.StatusBadRequest, true},
+		{"a plain error", errors.New("boom"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mapped := StatusOf(tt.err)
+			if got != tt.want || mapped != tt.mapped {
+				t.Fatalf("StatusOf() = %d, %v, want %d, %v", got, mapped, tt.want, tt.mapped)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package GenericPanicCatcher
 
 import (
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	ctx "context"
 	"log/slog"
@@ -28,7 +29,7 @@ func new(next http.Handler) http.Handler {
 		defer func() {
 			if rec := recover(); rec != nil {
 				logPanic(logger, context, rec, jobName)
-				w.WriteHeader(http.StatusInternalServerError)
+				Problem.Internal(context, w, logger, "Internal Server Error")
 			}
 		}()
 
@@ -45,6 +45,8 @@ func (l *LoggerAttacher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	logger = logger.With("JobName", jobName)
 
 	ctx = context.WithValue(ctx, ContextKeys.Logger, logger)
+	ctx = context.WithValue(ctx, ContextKeys.RequestID, requestId.String())
+	w.Header().Set("X-Request-ID", requestId.String())
 	r = r.WithContext(ctx)
 
 	l.next.ServeHTTP(w, r)
@@ -0,0 +1,42 @@
+package Classes
+
+import (
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/ClassesUseCase"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+type Struct struct {
+	uc ClassesUseCase.Interface
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc ClassesUseCase.Interface) *Struct {
+	return &Struct{uc}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger, err := Logging.RetrieveLogger(ctx)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
+		return
+	}
+
+	responseBody, err := json.Marshal(Response{s.uc.List()})
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Classes.ServeHTTP: Could not marshal response", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(responseBody); err != nil {
+		logger.ErrorContext(ctx, "Classes.ServeHTTP: Failed to write response", slog.Any("Error", err))
+	}
+}
@@ -0,0 +1,7 @@
+package Classes
+
+import "ChoHanJi/domain/Class"
+
+type Response struct {
+	Classes []Class.Struct `json:"Classes"`
+}
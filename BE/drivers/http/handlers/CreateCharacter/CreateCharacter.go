@@ -1,9 +1,9 @@
 package CreateCharacter
 
 import (
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/CharacterFactory"
-	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
@@ -28,7 +28,7 @@ func (c *CreateCharacter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger, err := Logging.RetrieveLogger(ctx)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not resolve the logger", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
 		return
 	}
 
@@ -38,58 +38,48 @@ func (c *CreateCharacter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	request, err := io.ReadAll(requestBody)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not read the request", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
 		return
 	}
 
 	// Unmarshal
 	var data Request
 	if err := json.Unmarshal(request, &data); err != nil {
-		sendBack500(ctx, w, logger, "Could not unmarshal the body", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Could not unmarshal the body", err)
 		return
 	}
 
 	// Validate
 	if err := c.validator.Struct(data); err != nil {
-		sendBack400(ctx, w, logger, "Request body failed at validation", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Request body failed at validation", err)
 		return
 	}
 
 	characterId, err := c.uc.CreateCharacter(data.RoomId, data.UserName, data.Class, data.TeamNumber)
 	if err != nil {
-		sendBack400(ctx, w, logger, "Could not create the character", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Could not create the character", err)
 		return
 	}
 
 	resp := Response{characterId}
 	responseBody, err := json.Marshal(resp)
 	if err != nil {
-		sendBack500(ctx, w, logger, "CreateRoom.ServeHTTP: Could not marshal response", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "CreateRoom.ServeHTTP: Could not marshal response", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 	if _, err = w.Write(responseBody); err != nil {
-		sendBack500(ctx, w, logger, "CreateRoom.ServeHTTP: Failed to write response", err)
-		return
+		logger.ErrorContext(ctx, "CreateRoom.ServeHTTP: Failed to write response", slog.Any("Error", err))
 	}
 }
 
-func sendBack400(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusBadRequest)
-}
-
-func sendBack500(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusInternalServerError)
-}
-
 type Request struct {
-	RoomId     string `json:"RoomId" validate:"required"`
-	UserName   string `json:"UserName" validate:"required"`
-	Class      string `json:"Class" validate:"required"`
-	TeamNumber int    `json:"TeamNumber" validate:"required,gt=0"`
+	RoomId   string `json:"RoomId" validate:"required"`
+	UserName string `json:"UserName" validate:"required"`
+	Class    string `json:"Class" validate:"required"`
+	// TeamNumber is ignored in free-for-all rooms, where every player gets a team of their own.
+	TeamNumber int `json:"TeamNumber" validate:"gte=0"`
 }
 
 type Response struct {
@@ -1,9 +1,12 @@
 package CreateRoom
 
 import (
+	"ChoHanJi/domain/Item"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/domain/Team"
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	RoomFactoryPorts "ChoHanJi/useCases/RoomFactory/ports"
-	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
@@ -27,7 +30,7 @@ func (c *CreateRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger, err := Logging.RetrieveLogger(ctx)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not resolve the logger", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
 		return
 	}
 
@@ -37,49 +40,70 @@ func (c *CreateRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	request, err := io.ReadAll(requestBody)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not read the request", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
 		return
 	}
 
 	// Unmarshal
 	var data Request
 	if err := json.Unmarshal(request, &data); err != nil {
-		sendBack500(ctx, w, logger, "Could not unmarshal the body", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Could not unmarshal the body", err)
 		return
 	}
 
 	// Validate
 	if err := c.validator.Struct(data); err != nil {
-		sendBack400(ctx, w, logger, "Request body failed at validation", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Request body failed at validation", err)
 		return
 	}
 
-	mapId, err := c.roomFactory.Create(data.MapWidth, data.MapHeight, data.Items)
+	items, err := Item.ParseSpecs(data.Items)
 	if err != nil {
-		sendBack400(ctx, w, logger, "Failed to create room", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Invalid items", err)
+		return
+	}
+
+	mapId, err := c.roomFactory.Create(data.MapWidth, data.MapHeight, items, Room.Settings{
+		FogOfWar:             data.FogOfWar,
+		PlanningSeconds:      data.PlanningSeconds,
+		RequireAllReady:      data.RequireAllReady,
+		AllowedClasses:       data.AllowedClasses,
+		ClassLimits:          data.ClassLimits,
+		DeathDrop:            Room.DeathDrop(data.DeathDrop),
+		ChestDefense:         data.ChestDefense,
+		Scatter:              data.Scatter,
+		Layout:               data.Layout,
+		Teams:                teams(data.Teams),
+		FreeForAll:           data.FreeForAll,
+		AutoAssign:           Room.AutoAssign(data.AutoAssign),
+		TeamSize:             data.TeamSize,
+		LateJoin:             Room.LateJoin(data.LateJoin),
+		RespawnTurns:         data.RespawnTurns,
+		SpawnInvulnerability: data.SpawnInvulnerability,
+		DeathPenalty:         data.DeathPenalty,
+	})
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Failed to create room", err)
 		return
 	}
 
 	res := Response{string(mapId)}
 	responseBody, err := json.Marshal(res)
 	if err != nil {
-		sendBack500(ctx, w, logger, "CreateRoom.ServeHTTP: Could not marshal response", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "CreateRoom.ServeHTTP: Could not marshal response", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 	if _, err = w.Write(responseBody); err != nil {
-		sendBack500(ctx, w, logger, "CreateRoom.ServeHTTP: Failed to write response", err)
-		return
+		logger.ErrorContext(ctx, "CreateRoom.ServeHTTP: Failed to write response", slog.Any("Error", err))
 	}
 }
 
-func sendBack400(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusBadRequest)
-}
-
-func sendBack500(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusInternalServerError)
+func teams(requested []TeamRequest) []Team.Struct {
+	teams := make([]Team.Struct, len(requested))
+	for i, team := range requested {
+		teams[i] = Team.Struct{Name: team.Name, Color: team.Color}
+	}
+	return teams
 }
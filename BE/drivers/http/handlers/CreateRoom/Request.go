@@ -1,7 +1,52 @@
 package CreateRoom
 
+import (
+	m "ChoHanJi/domain/Map"
+	"encoding/json"
+)
+
 type Request struct {
-	MapWidth  int    `json:"MapWidth" validate:"required,gt=0"`
-	MapHeight int    `json:"MapHeight" validate:"required,gt=0"`
-	Items     string `json:"Items" validate:"required"`
+	MapWidth  int `json:"MapWidth" validate:"required,gt=0"`
+	MapHeight int `json:"MapHeight" validate:"required,gt=0"`
+	// Items is a list of item specs, or the names of the treasures separated by commas.
+	Items    json.RawMessage `json:"Items" validate:"required"`
+	FogOfWar bool            `json:"FogOfWar"`
+	// PlanningSeconds enables the planning timer when greater than zero.
+	PlanningSeconds int `json:"PlanningSeconds" validate:"gte=0,lte=600"`
+	// RequireAllReady keeps the admin from proceeding until every player is ready.
+	RequireAllReady bool `json:"RequireAllReady"`
+	// AllowedClasses lists the classes the players may pick. Empty allows them all.
+	AllowedClasses []string `json:"AllowedClasses" validate:"omitempty,dive,required"`
+	// ClassLimits caps how many players of a class each team may have.
+	ClassLimits map[string]int `json:"ClassLimits" validate:"omitempty,dive,keys,required,endkeys,gte=0"`
+	// DeathDrop is what a dying player drops, the whole inventory by default.
+	DeathDrop string `json:"DeathDrop" validate:"omitempty,oneof=All One"`
+	// ChestDefense makes a raider fight a defender standing by the chest first.
+	ChestDefense bool `json:"ChestDefense"`
+	// Scatter places the items on the map and scatters the raided chests.
+	Scatter m.Scatter `json:"Scatter"`
+	// Layout places the spawns of the teams and the inaccessible tiles.
+	Layout m.Layout `json:"Layout"`
+	// Teams names and colors the teams, two of them by default.
+	Teams []TeamRequest `json:"Teams" validate:"omitempty,min=2,max=6,dive"`
+	// FreeForAll gives every player a team of their own, seating one player per team.
+	FreeForAll bool `json:"FreeForAll"`
+	// AutoAssign picks the team of the joining players, balancing the player count or the class mix.
+	AutoAssign string `json:"AutoAssign" validate:"omitempty,oneof=Count Class"`
+	// TeamSize caps how many players a team may have. Zero leaves it open.
+	TeamSize int `json:"TeamSize" validate:"gte=0"`
+	// LateJoin spawns the players joining a started game the next turn or lets them watch only. Empty refuses them.
+	LateJoin string `json:"LateJoin" validate:"omitempty,oneof=NextTurn Spectator"`
+	// RespawnTurns is how many turns a dead player sits out before respawning.
+	RespawnTurns int `json:"RespawnTurns" validate:"gte=0,lte=20"`
+	// SpawnInvulnerability keeps the respawned players from dying for a turn.
+	SpawnInvulnerability bool `json:"SpawnInvulnerability"`
+	// DeathPenalty is how many points a death costs the team of the player.
+	DeathPenalty int `json:"DeathPenalty" validate:"gte=0"`
+}
+
+// TeamRequest names and colors a team. Blank fields get the defaults.
+type TeamRequest struct {
+	Name  string `json:"Name" validate:"max=32"`
+	Color string `json:"Color" validate:"omitempty,hexcolor"`
 }
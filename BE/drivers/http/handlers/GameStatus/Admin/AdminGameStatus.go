@@ -1,6 +1,7 @@
 package AdminGameStatus
 
 import (
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/GameStatus"
 	"io"
@@ -31,19 +32,19 @@ func (a *AdminGameStatus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	ioWriter, ok := w.(io.Writer)
 	if !ok {
-		w.WriteHeader(http.StatusInternalServerError)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "The response cannot be streamed", Problem.ErrNotStreamable)
 		return
 	}
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		w.WriteHeader(http.StatusInternalServerError)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "The response cannot be streamed", Problem.ErrNotStreamable)
 		return
 	}
 
 	roomId := r.URL.Query().Get("roomId")
 	if len(strings.TrimSpace(roomId)) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		Problem.MissingParameter(ctx, w, logger, "roomId")
 		return
 	}
 
@@ -1,6 +1,7 @@
 package PlayerGameStatus
 
 import (
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/GameStatus"
 	"io"
@@ -31,20 +32,24 @@ func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	ioWriter, ok := w.(io.Writer)
 	if !ok {
-		w.WriteHeader(http.StatusInternalServerError)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "The response cannot be streamed", Problem.ErrNotStreamable)
 		return
 	}
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		w.WriteHeader(http.StatusInternalServerError)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "The response cannot be streamed", Problem.ErrNotStreamable)
 		return
 	}
 
 	roomId := r.URL.Query().Get("roomId")
 	playerId := r.URL.Query().Get("playerId")
-	if len(strings.TrimSpace(roomId)) == 0 || len(strings.TrimSpace(playerId)) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+	if len(strings.TrimSpace(playerId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "playerId")
 		return
 	}
 
@@ -0,0 +1,86 @@
+package SpectatorGameStatus
+
+import (
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/GameStatus"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Struct struct {
+	uc GameStatus.Interface
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc GameStatus.Interface) *Struct {
+	return &Struct{uc}
+}
+
+// ServeHTTP implements http.Handler.
+// The optional fog query parameter is a boolean and delay is in seconds.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ctx := r.Context()
+
+	logger, _ := Logging.RetrieveLogger(ctx)
+
+	ioWriter, ok := w.(io.Writer)
+	if !ok {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "The response cannot be streamed", Problem.ErrNotStreamable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "The response cannot be streamed", Problem.ErrNotStreamable)
+		return
+	}
+
+	query := r.URL.Query()
+
+	roomId := query.Get("roomId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+
+	var options GameStatus.SpectateOptions
+
+	if fog := query.Get("fog"); fog != "" {
+		parsed, err := strconv.ParseBool(fog)
+		if err != nil {
+			Problem.InvalidParameter(ctx, w, logger, "fog", "boolean", "fog must be true or false")
+			return
+		}
+		options.Fog = parsed
+	}
+
+	if delay := query.Get("delay"); delay != "" {
+		seconds, err := strconv.Atoi(delay)
+		if err != nil || seconds < 0 || time.Duration(seconds)*time.Second > GameStatus.MaxSpectatorDelay {
+			max := int(GameStatus.MaxSpectatorDelay / time.Second)
+			Problem.InvalidParameter(ctx, w, logger, "delay", fmt.Sprintf("min=0,max=%d", max), fmt.Sprintf("delay must be 0 to %d seconds", max))
+			return
+		}
+		options.Delay = time.Duration(seconds) * time.Second
+	}
+
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if err := s.uc.Spectate(ctx, ioWriter, roomId, options, flusher); err != nil {
+		logger.ErrorContext(ctx, "Something went wrong...", slog.Any("Error", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
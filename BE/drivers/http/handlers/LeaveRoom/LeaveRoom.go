@@ -0,0 +1,76 @@
+package LeaveRoom
+
+import (
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/LobbyUseCase"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type Struct struct {
+	uc        LobbyUseCase.Interface
+	validator *validator.Validate
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc LobbyUseCase.Interface, validator *validator.Validate) *Struct {
+	return &Struct{uc, validator}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger, err := Logging.RetrieveLogger(ctx)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
+		return
+	}
+
+	// Get Data
+	requestBody := r.Body
+	defer requestBody.Close()
+
+	request, err := io.ReadAll(requestBody)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
+		return
+	}
+
+	roomId := r.URL.Query().Get("roomId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+
+	// Unmarshal
+	var data Request
+	if err := json.Unmarshal(request, &data); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Could not unmarshal the body", err)
+		return
+	}
+
+	// Validate
+	if err := s.validator.Struct(data); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Request body failed at validation", err)
+		return
+	}
+
+	if err := s.uc.Leave(Room.Id(roomId), Player.Id(data.PlayerId)); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not leave the room", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type Request struct {
+	PlayerId string `json:"PlayerId" validate:"required"`
+}
@@ -0,0 +1,68 @@
+package PendingActions
+
+import (
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/PendingActionsUseCase"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type Struct struct {
+	uc PendingActionsUseCase.Interface
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc PendingActionsUseCase.Interface) *Struct {
+	return &Struct{uc}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger, err := Logging.RetrieveLogger(ctx)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
+		return
+	}
+
+	roomId := r.URL.Query().Get("roomId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+
+	// A player names themselves to get the part of the list they can see.
+	playerId := r.URL.Query().Get("playerId")
+
+	result, err := s.uc.List(Room.Id(roomId), Player.Id(playerId))
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Something went wrong...", err)
+		return
+	}
+
+	responseBody, err := json.Marshal(Response{toStrings(result.Submitted), toStrings(result.Pending)})
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "PendingActions.ServeHTTP: Could not marshal response", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(responseBody); err != nil {
+		logger.ErrorContext(ctx, "PendingActions.ServeHTTP: Failed to write response", slog.Any("Error", err))
+	}
+}
+
+func toStrings(ids []Player.Id) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, string(id))
+	}
+	return strs
+}
@@ -0,0 +1,6 @@
+package PendingActions
+
+type Response struct {
+	Submitted []string `json:"Submitted"`
+	Pending   []string `json:"Pending"`
+}
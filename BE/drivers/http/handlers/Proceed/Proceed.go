@@ -1,10 +1,9 @@
 package Proceed
 
 import (
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/ProceedUseCase"
-	"context"
-	"log/slog"
 	"net/http"
 	"strings"
 )
@@ -24,29 +23,19 @@ func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger, err := Logging.RetrieveLogger(ctx)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not resolve the logger", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
 		return
 	}
 
 	// Get Data
 	roomId := r.URL.Query().Get("roomId")
 	if len(strings.TrimSpace(roomId)) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		Problem.MissingParameter(ctx, w, logger, "roomId")
 		return
 	}
 
 	if err := s.uc.Proceed(ctx, roomId, logger); err != nil {
-		sendBack400(ctx, w, logger, "Something went wrong...", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Something went wrong...", err)
 		return
 	}
 }
-
-func sendBack400(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusBadRequest)
-}
-
-func sendBack500(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusInternalServerError)
-}
@@ -0,0 +1,62 @@
+package Reachable
+
+import (
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/ReachableUseCase"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type Struct struct {
+	uc ReachableUseCase.Interface
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc ReachableUseCase.Interface) *Struct {
+	return &Struct{uc}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger, err := Logging.RetrieveLogger(ctx)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
+		return
+	}
+
+	roomId := r.URL.Query().Get("roomId")
+	playerId := r.URL.Query().Get("playerId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+	if len(strings.TrimSpace(playerId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "playerId")
+		return
+	}
+
+	tiles, err := s.uc.List(Room.Id(roomId), Player.Id(playerId))
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Something went wrong...", err)
+		return
+	}
+
+	responseBody, err := json.Marshal(Response{tiles})
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Reachable.ServeHTTP: Could not marshal response", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(responseBody); err != nil {
+		logger.ErrorContext(ctx, "Reachable.ServeHTTP: Failed to write response", slog.Any("Error", err))
+	}
+}
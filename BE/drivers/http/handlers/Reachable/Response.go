@@ -0,0 +1,7 @@
+package Reachable
+
+import "ChoHanJi/domain/Movement"
+
+type Response struct {
+	Tiles []Movement.Position `json:"Tiles"`
+}
@@ -0,0 +1,5 @@
+package RequireAllReady
+
+type Request struct {
+	Enabled *bool `json:"Enabled" validate:"required"`
+}
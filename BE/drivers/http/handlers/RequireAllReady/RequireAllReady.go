@@ -0,0 +1,70 @@
+package RequireAllReady
+
+import (
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/ProceedUseCase"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type Struct struct {
+	uc        ProceedUseCase.Interface
+	validator *validator.Validate
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc ProceedUseCase.Interface, validator *validator.Validate) *Struct {
+	return &Struct{uc, validator}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger, err := Logging.RetrieveLogger(ctx)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
+		return
+	}
+
+	// Get Data
+	requestBody := r.Body
+	defer requestBody.Close()
+
+	request, err := io.ReadAll(requestBody)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
+		return
+	}
+
+	roomId := r.URL.Query().Get("roomId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+
+	// Unmarshal
+	var data Request
+	if err := json.Unmarshal(request, &data); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Could not unmarshal the body", err)
+		return
+	}
+
+	// Validate
+	if err := s.validator.Struct(data); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Request body failed at validation", err)
+		return
+	}
+
+	if err := s.uc.SetRequireAllReady(roomId, *data.Enabled); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Something went wrong...", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -5,15 +5,30 @@ type RouteToken string
 var (
 	POSTRoom               RouteToken = "/api/room"
 	POSTCharacter          RouteToken = "/api/character"
+	GETClasses             RouteToken = "/api/classes"
 	GETPlayerEvent         RouteToken = "/api/player/event"
 	GETRoomAdmin           RouteToken = "/api/waiting/room/admin"
 	POSTGameStart          RouteToken = "/api/game/start"
 	GETPlayerGameStatus    RouteToken = "/api/game/player"
 	GETAdminGameStatus     RouteToken = "/api/game/admin"
+	GETSpectatorGameStatus RouteToken = "/api/game/spectate"
 	POSTSubmitMoves        RouteToken = "/api/game/move"
 	POSTSubmitAttacks      RouteToken = "/api/game/attack"
 	POSTSubmitAttackResult RouteToken = "/api/game/attack/result"
 	POSTSubmitBonusAttacks RouteToken = "/api/game/bonusAttack"
+	POSTSubmitAbility      RouteToken = "/api/game/ability"
+	POSTSubmitDrop         RouteToken = "/api/game/drop"
+	POSTSubmitUse          RouteToken = "/api/game/use"
+	POSTSubmitRaid         RouteToken = "/api/game/raid"
 	POSTSubmitSkip         RouteToken = "/api/game/skip"
+	POSTWithdraw           RouteToken = "/api/game/withdraw"
+	GETPendingActions      RouteToken = "/api/game/pending"
+	GETReachable           RouteToken = "/api/game/reachable"
 	POSTProceed            RouteToken = "/api/game/proceed"
+	POSTRequireAllReady    RouteToken = "/api/game/requireAllReady"
+	GETWebSocket           RouteToken = "/api/ws"
+	POSTLobbyMove          RouteToken = "/api/lobby/move"
+	POSTLobbyKick          RouteToken = "/api/lobby/kick"
+	POSTLobbyLeave         RouteToken = "/api/lobby/leave"
+	POSTLobbyRename        RouteToken = "/api/lobby/rename"
 )
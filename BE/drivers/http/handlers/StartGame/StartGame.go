@@ -1,9 +1,9 @@
 package StartGame
 
 import (
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/StartGameUseCase"
-	"log/slog"
 	"net/http"
 	"strings"
 )
@@ -26,11 +26,12 @@ func (s *StartGame) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	roomId := r.URL.Query().Get("roomId")
 	if len(strings.TrimSpace(roomId)) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		Problem.MissingParameter(ctx, w, logger, "roomId")
 		return
 	}
 
 	if err := s.uc.Announce(roomId); err != nil {
-		logger.ErrorContext(ctx, "Something went wrong...", slog.Any("Error", err))
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not start the game", err)
+		return
 	}
 }
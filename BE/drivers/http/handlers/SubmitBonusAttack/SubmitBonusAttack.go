@@ -3,12 +3,10 @@ package SubmitBonusAttack
 import (
 	"ChoHanJi/domain/Action"
 	"ChoHanJi/domain/Room"
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/SubmitMoveUseCase"
-	"context"
-	"errors"
 	"io"
-	"log/slog"
 	"net/http"
 	"strings"
 
@@ -31,7 +29,7 @@ func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger, err := Logging.RetrieveLogger(ctx)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not resolve the logger", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
 		return
 	}
 
@@ -41,35 +39,20 @@ func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	request, err := io.ReadAll(requestBody)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not read the request", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
 		return
 	}
 
 	roomId := r.URL.Query().Get("roomId")
 	if len(strings.TrimSpace(roomId)) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		Problem.MissingParameter(ctx, w, logger, "roomId")
 		return
 	}
 
 	if err := s.uc.Submit(Room.Id(roomId), Action.BonusAttack, request); err != nil {
-		switch {
-		case errors.Is(err, SubmitMoveUseCase.ErrWrongInput):
-			sendBack400(ctx, w, logger, "Wrong Submission", err)
-		default:
-			sendBack500(ctx, w, logger, "Something went wrong...", err)
-		}
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not submit the action", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 }
-
-func sendBack400(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusBadRequest)
-}
-
-func sendBack500(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusInternalServerError)
-}
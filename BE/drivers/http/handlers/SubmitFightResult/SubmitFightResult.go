@@ -4,12 +4,11 @@ import (
 	"ChoHanJi/domain/Fight"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/Room"
+	"ChoHanJi/drivers/http/Problem"
 	"ChoHanJi/infrastructure/Logging"
 	"ChoHanJi/useCases/SubmitFightResultUseCase"
-	"context"
 	"encoding/json"
 	"io"
-	"log/slog"
 	"net/http"
 	"strings"
 
@@ -31,7 +30,7 @@ func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger, err := Logging.RetrieveLogger(ctx)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not resolve the logger", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
 		return
 	}
 
@@ -40,41 +39,31 @@ func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	requestBytes, err := io.ReadAll(body)
 	if err != nil {
-		sendBack500(ctx, w, logger, "Could not read the request", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
 		return
 	}
 
 	roomId := r.URL.Query().Get("roomId")
 	if len(strings.TrimSpace(roomId)) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		Problem.MissingParameter(ctx, w, logger, "roomId")
 		return
 	}
 
 	var req SubmitFightResultUseCase.Request
 	if err := json.Unmarshal(requestBytes, &req); err != nil {
-		sendBack400(ctx, w, logger, "Wrong Submission", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Wrong Submission", err)
 		return
 	}
 
 	if err := s.validator.Struct(req); err != nil {
-		sendBack400(ctx, w, logger, "Wrong Submission", err)
+		Problem.Send(ctx, w, logger, http.StatusBadRequest, "Wrong Submission", err)
 		return
 	}
 
 	if err := s.uc.Submit(Room.Id(roomId), Fight.Id(req.FightId), Player.Id(req.SubmitterId), Player.Id(req.WinnerId)); err != nil {
-		sendBack500(ctx, w, logger, "Something went wrong...", err)
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Something went wrong...", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 }
-
-func sendBack400(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusBadRequest)
-}
-
-func sendBack500(ctx context.Context, w http.ResponseWriter, logger *slog.Logger, errMsg string, err error) {
-	logger.ErrorContext(ctx, errMsg, slog.Any("Error", err))
-	w.WriteHeader(http.StatusInternalServerError)
-}
@@ -0,0 +1,89 @@
+package WebSocket
+
+import (
+	"ChoHanJi/config/PilgrimCraftConfig"
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/WebSocketUseCase"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/coder/websocket"
+)
+
+type Struct struct {
+	uc     WebSocketUseCase.Interface
+	origin string
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+// New accepts the connections from the origin the API answers. The origin
+// patterns of the websockets leave the scheme out.
+func New(uc WebSocketUseCase.Interface, config *PilgrimCraftConfig.PilgrimCraftConfig) *Struct {
+	origin := config.Server.Origin()
+	if parsed, err := url.Parse(origin); err == nil && parsed.Host != "" {
+		origin = parsed.Host
+	}
+
+	return &Struct{uc, origin}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger, _ := Logging.RetrieveLogger(ctx)
+
+	roomId := r.URL.Query().Get("roomId")
+	playerId := r.URL.Query().Get("playerId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+	if len(strings.TrimSpace(playerId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "playerId")
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: []string{s.origin},
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Could not accept the websocket connection", slog.Any("Error", err))
+		return
+	}
+	defer conn.CloseNow()
+
+	if err := s.uc.ConnectAndListen(ctx, &connection{conn}, roomId, playerId); err != nil {
+		logger.ErrorContext(ctx, "Something went wrong...", slog.Any("Error", err))
+
+		status := websocket.StatusInternalError
+		if errors.Is(err, WebSocketUseCase.ErrNotFound) {
+			status = websocket.StatusPolicyViolation
+		}
+		_ = conn.Close(status, err.Error())
+		return
+	}
+
+	_ = conn.Close(websocket.StatusNormalClosure, "")
+}
+
+type connection struct {
+	conn *websocket.Conn
+}
+
+var _ WebSocketUseCase.Connection = (*connection)(nil)
+
+func (c *connection) Read(ctx context.Context) ([]byte, error) {
+	_, msg, err := c.conn.Read(ctx)
+	return msg, err
+}
+
+func (c *connection) Write(ctx context.Context, msg []byte) error {
+	return c.conn.Write(ctx, websocket.MessageText, msg)
+}
@@ -0,0 +1,55 @@
+package Withdraw
+
+import (
+	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/drivers/http/Problem"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/SubmitMoveUseCase"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type Struct struct {
+	uc SubmitMoveUseCase.Interface
+}
+
+var _ http.Handler = (*Struct)(nil)
+
+func New(uc SubmitMoveUseCase.Interface) *Struct {
+	return &Struct{uc}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Struct) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger, err := Logging.RetrieveLogger(ctx)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not resolve the logger", err)
+		return
+	}
+
+	// Get Data
+	requestBody := r.Body
+	defer requestBody.Close()
+
+	request, err := io.ReadAll(requestBody)
+	if err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not read the request", err)
+		return
+	}
+
+	roomId := r.URL.Query().Get("roomId")
+	if len(strings.TrimSpace(roomId)) == 0 {
+		Problem.MissingParameter(ctx, w, logger, "roomId")
+		return
+	}
+
+	if err := s.uc.Submit(Room.Id(roomId), Action.Withdraw, request); err != nil {
+		Problem.Send(ctx, w, logger, http.StatusInternalServerError, "Could not submit the action", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -3,6 +3,7 @@ package ContextKeys
 type ContextKeys string
 
 const (
-	JobName ContextKeys = "JobName"
-	Logger  ContextKeys = "Logger"
+	JobName   ContextKeys = "JobName"
+	Logger    ContextKeys = "Logger"
+	RequestID ContextKeys = "RequestID"
 )
@@ -0,0 +1,15 @@
+package Logging
+
+import (
+	"ChoHanJi/infrastructure/ContextKeys"
+	"context"
+	"errors"
+)
+
+func GetRequestId(ctx context.Context) (string, error) {
+	requestId, ok := ctx.Value(ContextKeys.RequestID).(string)
+	if !ok {
+		return "", errors.New("either the requestId is not found or is not of string")
+	}
+	return requestId, nil
+}
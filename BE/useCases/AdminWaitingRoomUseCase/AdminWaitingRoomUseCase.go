@@ -1,8 +1,9 @@
 package AdminWaitingRoomUseCase
 
 import (
+	"ChoHanJi/domain/Event"
 	r "ChoHanJi/domain/Room"
-	"ChoHanJi/driven/sse/SSEHub"
+	HubPorts "ChoHanJi/driven/ports"
 	"ChoHanJi/infrastructure/Logging"
 	"context"
 	"fmt"
@@ -17,7 +18,7 @@ type IHub interface {
 	Unsubscribe(roomId, subscriberId string) error
 }
 
-var _ IHub = (*SSEHub.Struct)(nil)
+var _ IHub = (HubPorts.HubInterface)(nil)
 
 type UseCaseInterface interface {
 	ConnectAndListen(ctx context.Context, w io.Writer, roomId string, flusher http.Flusher) error
@@ -37,7 +38,8 @@ func New(rooms r.Rooms, hub IHub) *AdminWaitingRoomUseCase {
 func (uc *AdminWaitingRoomUseCase) ConnectAndListen(ctx context.Context, w io.Writer, roomId string, flusher http.Flusher) error {
 	logger, _ := Logging.RetrieveLogger(ctx)
 
-	if _, found := uc.rooms[r.Id(roomId)]; !found {
+	room, found := uc.rooms[r.Id(roomId)]
+	if !found {
 		return fmt.Errorf("room does not exist")
 	}
 
@@ -48,8 +50,22 @@ func (uc *AdminWaitingRoomUseCase) ConnectAndListen(ctx context.Context, w io.Wr
 		}
 	}()
 
-	connectedMessage := fmt.Sprintf(`{"MessageType":"Connection","Message":"Connected to the room %s"}`, roomId)
-	_, err := fmt.Fprintf(w, "data: %s\n\n", connectedMessage)
+	connectedMessage, err := Event.Encode(Event.LobbyConnectionStruct{RoomId: roomId})
+	if err != nil {
+		return err
+	}
+
+	rosters, err := Event.Encode(Event.TeamRostersStruct{Teams: room.Rosters()})
+	if err != nil {
+		return err
+	}
+
+	ping, err := Event.Encode(Event.PingStruct{})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "data: %s\n\ndata: %s\n\n", connectedMessage, rosters)
 	if err != nil {
 		logger.ErrorContext(ctx, "Could not send connected message")
 		return fmt.Errorf("could not write message, %s", connectedMessage)
@@ -74,7 +90,7 @@ func (uc *AdminWaitingRoomUseCase) ConnectAndListen(ctx context.Context, w io.Wr
 			}
 			flusher.Flush()
 		case <-ticker.C:
-			_, err := fmt.Fprintf(w, "data: %s\n\n", `{"MessageType":"ping"}`)
+			_, err := fmt.Fprintf(w, "data: %s\n\n", ping)
 			if err != nil {
 				logger.ErrorContext(ctx, "Could not send ping")
 			}
@@ -1,37 +1,85 @@
 package CharacterFactory
 
 import (
+	"ChoHanJi/domain/Class"
+	"ChoHanJi/domain/Failure"
 	"ChoHanJi/domain/Player"
 	r "ChoHanJi/domain/Room"
-	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrNotFound        = Failure.New(Failure.NotFound, "not found")
+	ErrClassNotAllowed = Failure.New(Failure.Invalid, "the class is not allowed in this room")
+	ErrGameStarted     = Failure.New(Failure.Conflict, "the game has already started")
+	ErrSpectatorOnly   = Failure.New(Failure.Forbidden, "the game has already started, it can only be watched")
 )
 
 type UseCaseInterface interface {
 	CreateCharacter(string, string, string, int) (string, error)
 }
 
+type IClasses interface {
+	Get(name string) (Class.Struct, error)
+}
+
+var _ IClasses = (*Class.Registry)(nil)
+
 type CharacterFactory struct {
-	rooms r.Rooms
+	rooms   r.Rooms
+	classes IClasses
 }
 
 var _ UseCaseInterface = (*CharacterFactory)(nil)
 
-func New(rooms r.Rooms) *CharacterFactory {
-	return &CharacterFactory{rooms}
+func New(rooms r.Rooms, classes IClasses) *CharacterFactory {
+	return &CharacterFactory{rooms, classes}
 }
 
 // CreateCharacter implements ICharacterFactory.
-func (c *CharacterFactory) CreateCharacter(roomId string, name, class string, teamNumber int) (string, error) {
+func (c *CharacterFactory) CreateCharacter(roomId string, name, className string, teamNumber int) (string, error) {
 	room, found := c.rooms[r.Id(roomId)]
 	if !found {
-		return "", errors.New("the game room does not exist")
+		return "", fmt.Errorf("CharacterFactory.CreateCharacter: %s %w", "room", ErrNotFound)
 	}
 
-	player, err := Player.New(room.Players, name, class, teamNumber)
+	if room.Started {
+		switch room.Settings.LateJoin {
+		case r.LateJoinNextTurn:
+		case r.LateJoinSpectator:
+			return "", fmt.Errorf("CharacterFactory.CreateCharacter: %w", ErrSpectatorOnly)
+		default:
+			return "", fmt.Errorf("CharacterFactory.CreateCharacter: %w", ErrGameStarted)
+		}
+	}
+
+	class, err := c.classes.Get(className)
+	if err != nil {
+		return "", fmt.Errorf("CharacterFactory.CreateCharacter: %w", err)
+	}
+
+	allowed := room.Settings.AllowedClasses
+	if len(allowed) > 0 && !slices.Contains(allowed, class.Name) {
+		return "", fmt.Errorf("CharacterFactory.CreateCharacter: %w: %s", ErrClassNotAllowed, class.Name)
+	}
+
+	team, err := room.PickTeam(teamNumber, class.Name)
+	if err != nil {
+		return "", fmt.Errorf("CharacterFactory.CreateCharacter: team %d: %w", teamNumber, err)
+	}
+
+	player, err := Player.New(room.Players, name, className, class, team)
 	if err != nil {
 		return "", err
 	}
 
+	// A late joiner sits the turn being played out and spawns with the dead.
+	if room.Started {
+		player.Status.RespawnTurns = 1
+		room.Map.Hold(player)
+	}
+
 	room.Players[player.Id] = player
 
 	return string(player.Id), nil
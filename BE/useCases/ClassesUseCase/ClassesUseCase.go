@@ -0,0 +1,28 @@
+package ClassesUseCase
+
+import "ChoHanJi/domain/Class"
+
+type Interface interface {
+	List() []Class.Struct
+}
+
+type IClasses interface {
+	List() []Class.Struct
+}
+
+var _ IClasses = (*Class.Registry)(nil)
+
+type Struct struct {
+	classes IClasses
+}
+
+var _ Interface = (*Struct)(nil)
+
+func New(classes IClasses) *Struct {
+	return &Struct{classes}
+}
+
+// List implements Interface.
+func (s *Struct) List() []Class.Struct {
+	return s.classes.List()
+}
@@ -1,14 +1,21 @@
 package GameStatus
 
 import (
+	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/IdGenerator"
 	"ChoHanJi/domain/Item"
+	"ChoHanJi/domain/Map"
 	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Readiness"
 	"ChoHanJi/domain/Room"
+	"ChoHanJi/domain/UpdateMessage"
+	"ChoHanJi/domain/Visibility"
+	HubPorts "ChoHanJi/driven/ports"
 	"ChoHanJi/infrastructure/Logging"
-	"ChoHanJi/useCases/GameStatus/Messages"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -16,10 +23,28 @@ import (
 	"time"
 )
 
-var ErrNotFound error = errors.New("not found")
+var ErrNotFound error = Failure.New(Failure.NotFound, "not found")
+
+// MaxSpectatorDelay bounds how long a spectator stream may lag behind the game.
+const MaxSpectatorDelay = 10 * time.Minute
+
+// MaxSpectatorBacklog bounds how many messages a delayed spectator stream
+// holds back. A stream falling further behind is closed, and the spectator
+// reconnects to a fresh snapshot.
+const MaxSpectatorBacklog = 4096
 
 type Interface interface {
 	ConnectAndListen(ctx context.Context, w io.Writer, roomId string, playerId string, flusher http.Flusher) error
+	Spectate(ctx context.Context, w io.Writer, roomId string, options SpectateOptions, flusher http.Flusher) error
+}
+
+// SpectateOptions tunes what a spectator sees.
+// Fog shows the game as a viewer of no team sees it: the board, the phases and
+// the scores, but neither the players, nor the items, nor the fights.
+// Delay holds every message back so the stream lags behind the game.
+type SpectateOptions struct {
+	Fog   bool
+	Delay time.Duration
 }
 
 type IHub interface {
@@ -27,15 +52,24 @@ type IHub interface {
 	Unsubscribe(roomId, subscriberId string) error
 }
 
+var _ IHub = (HubPorts.HubInterface)(nil)
+
+type IActionList interface {
+	GetSubmittedPlayers(roomId Room.Id) ([]Player.Id, error)
+}
+
+var _ IActionList = (*Action.List)(nil)
+
 type UseCase struct {
 	rooms   Room.Rooms
 	roomHub IHub
+	al      IActionList
 }
 
 var _ Interface = (*UseCase)(nil)
 
-func New(rooms Room.Rooms, roomHub IHub) *UseCase {
-	return &UseCase{rooms, roomHub}
+func New(rooms Room.Rooms, roomHub IHub, al IActionList) *UseCase {
+	return &UseCase{rooms, roomHub, al}
 }
 
 // ConnectAndListen implements GameStatusInterface.
@@ -59,12 +93,21 @@ func (g *UseCase) ConnectAndListen(ctx context.Context, w io.Writer, roomId stri
 		}
 	}()
 
-	msgBody, err := g.getConnectedMessage(room)
+	msgBody, err := g.getConnectedMessage(Room.Id(roomId), room, playerId)
+	if err != nil {
+		return err
+	}
+
+	connectedMessage, err := Event.Encode(msgBody)
+	if err != nil {
+		return err
+	}
+
+	ping, err := Event.Encode(Event.PingStruct{})
 	if err != nil {
 		return err
 	}
 
-	connectedMessage := fmt.Sprintf(`{"MessageType":"Connection","Message":%s}`, string(msgBody))
 	_, err = fmt.Fprintf(w, "data: %s\n\n", connectedMessage)
 	if err != nil {
 		logger.ErrorContext(ctx, "Could not send connected message")
@@ -90,7 +133,7 @@ func (g *UseCase) ConnectAndListen(ctx context.Context, w io.Writer, roomId stri
 			}
 			flusher.Flush()
 		case <-ticker.C:
-			_, err := fmt.Fprintf(w, "data: %s\n\n", `{"MessageType":"ping"}`)
+			_, err := fmt.Fprintf(w, "data: %s\n\n", ping)
 			if err != nil {
 				logger.ErrorContext(ctx, "Could not send ping")
 			}
@@ -101,7 +144,140 @@ func (g *UseCase) ConnectAndListen(ctx context.Context, w io.Writer, roomId stri
 	}
 }
 
-func (g *UseCase) getConnectedMessage(room *Room.Room) ([]byte, error) {
+// Spectate streams the game to a read-only subscriber. The spectator receives
+// the snapshot, the phases, the updates and every fight with its result, but
+// never the planning of the players.
+func (g *UseCase) Spectate(ctx context.Context, w io.Writer, roomId string, options SpectateOptions, flusher http.Flusher) error {
+	logger, _ := Logging.RetrieveLogger(ctx)
+
+	room, found := g.rooms[Room.Id(roomId)]
+	if !found {
+		return fmt.Errorf("GameStatusUseCase.Spectate: %s %w", "room", ErrNotFound)
+	}
+
+	id, err := IdGenerator.NewId()
+	if err != nil {
+		return err
+	}
+	spectatorId := HubPorts.SpectatorId(id)
+
+	ch := g.roomHub.Subscribe(roomId, spectatorId)
+	defer func() {
+		if err := g.roomHub.Unsubscribe(roomId, spectatorId); err != nil {
+			logger.Error("GameStatusUseCase.Spectate:Error Unsubscribing", slog.Any("Error", err))
+		}
+	}()
+
+	msgBody, err := g.getConnectedMessage(Room.Id(roomId), room, spectatorId)
+	if err != nil {
+		return err
+	}
+	if options.Fog {
+		msgBody = fogSnapshot(msgBody)
+	}
+
+	connectedMessage, err := Event.Encode(msgBody)
+	if err != nil {
+		return err
+	}
+
+	ping, err := Event.Encode(Event.PingStruct{})
+	if err != nil {
+		return err
+	}
+
+	send := func(msg []byte) {
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", msg); err != nil {
+			logger.ErrorContext(ctx, "Could not send message")
+		}
+		flusher.Flush()
+	}
+
+	type delayed struct {
+		at  time.Time
+		msg []byte
+	}
+	var pending []delayed
+
+	release := time.NewTimer(options.Delay)
+	release.Stop()
+	defer release.Stop()
+
+	queue := func(msg []byte) bool {
+		if options.Delay <= 0 {
+			send(msg)
+			return true
+		}
+		if len(pending) >= MaxSpectatorBacklog {
+			return false
+		}
+
+		pending = append(pending, delayed{time.Now().Add(options.Delay), msg})
+		if len(pending) == 1 {
+			release.Reset(options.Delay)
+		}
+		return true
+	}
+
+	queue(connectedMessage)
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message, ok := <-ch:
+			if !ok {
+				logger.ErrorContext(ctx, fmt.Sprintf("GameStatusUseCase.Spectate: Could not receive the message in the room, %s", roomId))
+				return nil
+			}
+
+			if options.Fog {
+				var shown bool
+				message, shown, err = fogMessage(room, message)
+				if err != nil {
+					logger.ErrorContext(ctx, "GameStatusUseCase.Spectate: Could not fog the message", slog.Any("Error", err))
+					continue
+				}
+				if !shown {
+					continue
+				}
+			}
+			if !queue(message) {
+				logger.ErrorContext(ctx, fmt.Sprintf("GameStatusUseCase.Spectate: The spectator fell more than %d messages behind the room, %s", MaxSpectatorBacklog, roomId))
+				return nil
+			}
+		case <-release.C:
+			now := time.Now()
+			for len(pending) > 0 && !pending[0].at.After(now) {
+				send(pending[0].msg)
+				pending = pending[1:]
+			}
+			if len(pending) > 0 {
+				release.Reset(time.Until(pending[0].at))
+			}
+		case <-ticker.C:
+			send(ping)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// GetConnectedMessage returns the snapshot sent to a subscriber when it connects to the room.
+func (g *UseCase) GetConnectedMessage(roomId string, subscriberId string) (*Event.ConnectionStruct, error) {
+	room, found := g.rooms[Room.Id(roomId)]
+	if !found {
+		return nil, fmt.Errorf("GameStatusUseCase.GetConnectedMessage: %s %w", "room", ErrNotFound)
+	}
+
+	return g.getConnectedMessage(Room.Id(roomId), room, subscriberId)
+}
+
+// getConnectedMessage hides what the subscriber cannot see when the room plays
+// with fog of war. The admin and the spectators see everything, and the admin
+// also gets the readiness of the players once the game started.
+func (g *UseCase) getConnectedMessage(roomId Room.Id, room *Room.Room, subscriberId string) (*Event.ConnectionStruct, error) {
 	height, err := room.Map.GetMapHeight()
 	if err != nil {
 		return nil, err
@@ -122,13 +298,75 @@ func (g *UseCase) getConnectedMessage(room *Room.Room) ([]byte, error) {
 		items = append(items, val)
 	}
 
-	message := Messages.ConnectedMessage{
+	if viewer, found := room.Players[Player.Id(subscriberId)]; found && room.Settings.FogOfWar {
+		players = Visibility.FilterPlayers(viewer, players)
+		items = Visibility.FilterItems(viewer, items)
+	}
+
+	var readiness *Readiness.Struct
+	if subscriberId == "admin" {
+		if submitted, err := g.al.GetSubmittedPlayers(roomId); err == nil {
+			readiness = Readiness.New(room.Players, submitted)
+		}
+	}
+
+	return &Event.ConnectionStruct{
 		MapHeight: height,
 		MapWidth:  width,
 		Tiles:     room.Map.GetSpecialTiles(),
 		Players:   players,
 		Items:     items,
+		Readiness: readiness,
+		Teams:     room.Settings.Teams,
+	}, nil
+}
+
+// fogSnapshot hides the players and the items from the snapshot, the ones on
+// the tiles included.
+func fogSnapshot(snapshot *Event.ConnectionStruct) *Event.ConnectionStruct {
+	neutral := Visibility.Neutral()
+
+	tiles := make([]*Map.Tile, 0, len(snapshot.Tiles))
+	for _, tile := range snapshot.Tiles {
+		hidden := *tile
+		hidden.Items = nil
+		hidden.Player = nil
+		tiles = append(tiles, &hidden)
+	}
+
+	return &Event.ConnectionStruct{
+		MapHeight: snapshot.MapHeight,
+		MapWidth:  snapshot.MapWidth,
+		Tiles:     tiles,
+		Players:   Visibility.FilterPlayers(neutral, snapshot.Players),
+		Items:     Visibility.FilterItems(neutral, snapshot.Items),
+		Teams:     snapshot.Teams,
+	}
+}
+
+// fogMessage filters the updates as a viewer of no team sees them and holds
+// back the fights and the raids, which tell where the players are. The other
+// messages are returned untouched.
+func fogMessage(room *Room.Room, message []byte) ([]byte, bool, error) {
+	var envelope Event.Envelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return nil, false, err
+	}
+
+	switch envelope.MessageType {
+	case Event.Fight, Event.FightResult, Event.ChestRaided:
+		return nil, false, nil
+	case Event.Update:
+	default:
+		return message, true, nil
+	}
+
+	changes := UpdateMessage.New()
+	if err := json.Unmarshal(envelope.Message, changes); err != nil {
+		return nil, false, err
 	}
 
-	return json.Marshal(message)
+	filtered := Visibility.FilterUpdateFor(Visibility.Neutral(), room.Players, room.Items, changes)
+	message, err := Event.Encode(Event.UpdateStruct{Struct: filtered})
+	return message, err == nil, err
 }
@@ -0,0 +1,139 @@
+package LobbyUseCase
+
+import (
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	HubPorts "ChoHanJi/driven/ports"
+	"fmt"
+)
+
+var (
+	ErrNotFound    = Failure.New(Failure.NotFound, "not found")
+	ErrGameStarted = Failure.New(Failure.Conflict, "the game has already started")
+)
+
+type IHub interface {
+	Publish(roomId, subscriberId string, event Event.Interface) error
+}
+
+var _ IHub = (HubPorts.HubInterface)(nil)
+
+// Interface is what the admin and the players do in the lobby while they wait
+// for the game.
+type Interface interface {
+	Move(roomId Room.Id, playerId Player.Id, team int) error
+	Kick(roomId Room.Id, playerId Player.Id) error
+	Leave(roomId Room.Id, playerId Player.Id) error
+	Rename(roomId Room.Id, playerId Player.Id, name string) error
+}
+
+type Struct struct {
+	rooms Room.Rooms
+	hub   IHub
+}
+
+var _ Interface = (*Struct)(nil)
+
+func New(rooms Room.Rooms, hub IHub) *Struct {
+	return &Struct{rooms, hub}
+}
+
+// Move puts the player in another team, on one of its spawns if they were
+// already on the map.
+func (s *Struct) Move(roomId Room.Id, playerId Player.Id, team int) error {
+	room, player, err := s.find(roomId, playerId)
+	if err != nil {
+		return fmt.Errorf("LobbyUseCase.Move: %w", err)
+	}
+
+	if player.TeamNumber == team {
+		return nil
+	}
+
+	if err := room.CanJoin(team, player.Class.Name, player.Id); err != nil {
+		return fmt.Errorf("LobbyUseCase.Move: team %d: %w", team, err)
+	}
+
+	placed := room.Map.RemovePlayer(player)
+	player.TeamNumber = team
+	if placed {
+		if err := room.Map.PlacePlayer(player); err != nil {
+			return fmt.Errorf("LobbyUseCase.Move: %w", err)
+		}
+	}
+
+	s.notify(roomId, room, playerId, Event.PlayerMovedStruct{PlayerId: playerId, Team: team})
+	return nil
+}
+
+// Kick takes the player out of the room and off the map.
+func (s *Struct) Kick(roomId Room.Id, playerId Player.Id) error {
+	room, player, err := s.find(roomId, playerId)
+	if err != nil {
+		return fmt.Errorf("LobbyUseCase.Kick: %w", err)
+	}
+
+	s.remove(room, player)
+
+	s.notify(roomId, room, playerId, Event.PlayerKickedStruct{PlayerId: playerId})
+	return nil
+}
+
+// Leave takes the player out of the room and off the map at their request.
+func (s *Struct) Leave(roomId Room.Id, playerId Player.Id) error {
+	room, player, err := s.find(roomId, playerId)
+	if err != nil {
+		return fmt.Errorf("LobbyUseCase.Leave: %w", err)
+	}
+
+	s.remove(room, player)
+
+	s.notify(roomId, room, playerId, Event.PlayerLeftStruct{PlayerId: playerId})
+	return nil
+}
+
+// Rename changes the name the player goes by.
+func (s *Struct) Rename(roomId Room.Id, playerId Player.Id, name string) error {
+	room, player, err := s.find(roomId, playerId)
+	if err != nil {
+		return fmt.Errorf("LobbyUseCase.Rename: %w", err)
+	}
+
+	player.Name = name
+
+	s.notify(roomId, room, playerId, Event.PlayerRenamedStruct{PlayerId: playerId, Name: name})
+	return nil
+}
+
+func (s *Struct) remove(room *Room.Room, player *Player.Struct) {
+	room.Map.RemovePlayer(player)
+	delete(room.Players, player.Id)
+}
+
+// find returns the room and the player, as long as the game has not started.
+func (s *Struct) find(roomId Room.Id, playerId Player.Id) (*Room.Room, *Player.Struct, error) {
+	room, found := s.rooms[roomId]
+	if !found {
+		return nil, nil, fmt.Errorf("%s %w", "room", ErrNotFound)
+	}
+
+	if room.Started {
+		return nil, nil, ErrGameStarted
+	}
+
+	player, found := room.Players[playerId]
+	if !found {
+		return nil, nil, fmt.Errorf("%s %w", "player", ErrNotFound)
+	}
+
+	return room, player, nil
+}
+
+// notify tells the player what happened to them and the admin the new
+// rosters. Either may not be listening, the change stands all the same.
+func (s *Struct) notify(roomId Room.Id, room *Room.Room, playerId Player.Id, event Event.Interface) {
+	_ = s.hub.Publish(string(roomId), string(playerId), event)
+	_ = s.hub.Publish(string(roomId), "admin", Event.TeamRostersStruct{Teams: room.Rosters()})
+}
@@ -0,0 +1,77 @@
+package PendingActionsUseCase
+
+import (
+	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	"ChoHanJi/domain/Visibility"
+	"fmt"
+	"slices"
+)
+
+var ErrNotFound = Failure.New(Failure.NotFound, "not found")
+
+type Interface interface {
+	List(roomId Room.Id, viewerId Player.Id) (*Result, error)
+}
+
+type IActionList interface {
+	GetSubmittedPlayers(roomId Room.Id) ([]Player.Id, error)
+}
+
+var _ IActionList = (*Action.List)(nil)
+
+// Result splits the players of the room by whether they submitted this turn.
+type Result struct {
+	Submitted []Player.Id
+	Pending   []Player.Id
+}
+
+type Struct struct {
+	rooms Room.Rooms
+	al    IActionList
+}
+
+var _ Interface = (*Struct)(nil)
+
+func New(rooms Room.Rooms, al IActionList) *Struct {
+	return &Struct{rooms, al}
+}
+
+// List implements Interface. Under fog of war, a viewer only hears of their
+// teammates and of the enemies in sight. Without a viewer, as for the admin,
+// every player is listed.
+func (s *Struct) List(roomId Room.Id, viewerId Player.Id) (*Result, error) {
+	room, found := s.rooms[roomId]
+	if !found {
+		return nil, fmt.Errorf("PendingActionsUseCase.List: %s %w", "room", ErrNotFound)
+	}
+
+	viewer, found := room.Players[viewerId]
+	if viewerId != "" && !found {
+		return nil, fmt.Errorf("PendingActionsUseCase.List: %s %w", "player", ErrNotFound)
+	}
+
+	submitted, err := s.al.GetSubmittedPlayers(roomId)
+	if err != nil {
+		return nil, fmt.Errorf("PendingActionsUseCase.List: %s %w", "game", ErrNotFound)
+	}
+
+	result := &Result{Submitted: make([]Player.Id, 0), Pending: make([]Player.Id, 0)}
+	for id, player := range room.Players {
+		if viewer != nil && room.Settings.FogOfWar && !Visibility.SeesPlayer(viewer, player) {
+			continue
+		}
+		if slices.Contains(submitted, id) {
+			result.Submitted = append(result.Submitted, id)
+		} else {
+			result.Pending = append(result.Pending, id)
+		}
+	}
+
+	slices.Sort(result.Submitted)
+	slices.Sort(result.Pending)
+
+	return result, nil
+}
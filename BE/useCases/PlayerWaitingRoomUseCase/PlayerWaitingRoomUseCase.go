@@ -1,12 +1,13 @@
 package PlayerWaitingRoomUseCase
 
 import (
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/Room"
-	"ChoHanJi/driven/sse/SSEHub"
+	HubPorts "ChoHanJi/driven/ports"
 	"ChoHanJi/infrastructure/Logging"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -17,12 +18,12 @@ import (
 type IHub interface {
 	Subscribe(roomId, subscriberId string) <-chan []byte
 	Unsubscribe(roomId, subscriberId string) error
-	Publish(roomId, adminId, messageType, messageBody string) error
+	Publish(roomId, subscriberId string, event Event.Interface) error
 }
 
-var _ IHub = (*SSEHub.Struct)(nil)
+var _ IHub = (HubPorts.HubInterface)(nil)
 
-var ErrNotFound error = errors.New("not found")
+var ErrNotFound error = Failure.New(Failure.NotFound, "not found")
 
 type PlayerWaitingRoomUseCase struct {
 	rooms   Room.Rooms
@@ -52,7 +53,9 @@ func (p *PlayerWaitingRoomUseCase) ConnectAndListen(ctx context.Context, w io.Wr
 		return fmt.Errorf("PlayerWaitingRoomUseCase.ConnectAndListen: %s %w", "player", ErrNotFound)
 	}
 
-	room.Map.PlacePlayer(player)
+	if err := room.Map.PlacePlayer(player); err != nil {
+		return fmt.Errorf("PlayerWaitingRoomUseCase.ConnectAndListen: Could not place the player: %w", err)
+	}
 
 	ch := p.roomHub.Subscribe(roomId, playerId)
 	defer func() {
@@ -61,19 +64,50 @@ func (p *PlayerWaitingRoomUseCase) ConnectAndListen(ctx context.Context, w io.Wr
 		}
 	}()
 
-	connectedMessage := fmt.Sprintf(`{"MessageType":"Connection","Message":"Connected to the room %s"}`, roomId)
-	_, err := fmt.Fprintf(w, "data: %s\n\n", connectedMessage)
+	connectedMessage, err := Event.Encode(Event.LobbyConnectionStruct{RoomId: roomId})
+	if err != nil {
+		return err
+	}
+
+	ping, err := Event.Encode(Event.PingStruct{})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "data: %s\n\n", connectedMessage)
 	if err != nil {
 		logger.ErrorContext(ctx, "Could not send connected message")
 		return fmt.Errorf("could not write message, %s", connectedMessage)
 	}
 	flusher.Flush()
 
-	if err := p.roomHub.Publish(roomId, "admin", "PlayerConnected", fmt.Sprintf(`{"id":"%s","name":"%s","team":%d}`, playerId, player.Name, player.TeamNumber)); err != nil {
+	// A late joiner has missed the start of the game and goes straight to it,
+	// the lobby being over.
+	if room.Started {
+		width, _ := room.Map.GetMapWidth()
+		height, _ := room.Map.GetMapHeight()
+		startMessage, err := Event.Encode(Event.GameStartStruct{RoomId: roomId, MapHeight: height, MapWidth: width})
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", startMessage); err != nil {
+			logger.ErrorContext(ctx, "Could not send game start message")
+			return fmt.Errorf("could not write message, %s", startMessage)
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	if err := p.roomHub.Publish(roomId, "admin", Event.PlayerConnectedStruct{Id: player.Id, Name: player.Name, Team: player.TeamNumber}); err != nil {
 		logger.ErrorContext(ctx, "PlayerWaitingRoomUseCase.ConnectAndListen: Could not announce player connected message", slog.Any("Error", err))
 		return fmt.Errorf("could not publish PlayerConnected message. PlayerId: %s", player.Id)
 	}
 
+	if err := p.roomHub.Publish(roomId, "admin", Event.TeamRostersStruct{Teams: room.Rosters()}); err != nil {
+		logger.WarnContext(ctx, "PlayerWaitingRoomUseCase.ConnectAndListen: Could not publish the team rosters", slog.Any("Error", err))
+	}
+
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
@@ -92,7 +126,7 @@ func (p *PlayerWaitingRoomUseCase) ConnectAndListen(ctx context.Context, w io.Wr
 			}
 			flusher.Flush()
 		case <-ticker.C:
-			_, err := fmt.Fprintf(w, "data: %s\n\n", `{"MessageType":"ping"}`)
+			_, err := fmt.Fprintf(w, "data: %s\n\n", ping)
 			if err != nil {
 				logger.ErrorContext(ctx, "Could not send ping")
 			}
@@ -2,29 +2,55 @@ package ProceedUseCase
 
 import (
 	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/PlayerBlocker"
+	"ChoHanJi/domain/Readiness"
 	"ChoHanJi/domain/Room"
+	HubPorts "ChoHanJi/driven/ports"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"runtime/debug"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound          = Failure.New(Failure.NotFound, "not found")
+	ErrAlreadyProceeding = Failure.New(Failure.Conflict, "the turn is already proceeding")
+	ErrNotAllReady       = Failure.New(Failure.Conflict, "not every player is ready")
 )
 
 type Interface interface {
 	Proceed(ctx context.Context, roomId string, logger *slog.Logger) error
+	SetRequireAllReady(roomId string, enabled bool) error
+}
+
+// IPlanningTimer advances the turns of the rooms playing with a planning timer.
+type IPlanningTimer interface {
+	StartPlanning(roomId Room.Id) error
+	PlayerSubmitted(roomId Room.Id)
 }
 
 type ActionList interface {
+	GetUseList(roomId Room.Id) ([]Action.ItemActionStruct, error)
+	GetDropList(roomId Room.Id) ([]Action.ItemActionStruct, error)
+	GetAbilityList(roomId Room.Id) ([]Action.AbilityStruct, error)
 	GetAttackActionList(roomId Room.Id) ([]Action.AttackStruct, error)
+	GetRaidList(roomId Room.Id) ([]Action.RaidStruct, error)
 	GetMoveActionList(roomId Room.Id) ([]Action.MoveStruct, error)
 	GetBonusAttackList(roomId Room.Id) ([]Action.BonusAttackStruct, error)
+	GetSubmittedPlayers(roomId Room.Id) ([]Player.Id, error)
 	Reset(roomId Room.Id) error
 }
 
 var _ ActionList = (*Action.List)(nil)
 
 type ActionProcessor interface {
-	Process(roomId Room.Id, attacks []Action.AttackStruct, moves []Action.MoveStruct, bonusAttacks []Action.BonusAttackStruct) error
+	Process(roomId Room.Id, uses, drops []Action.ItemActionStruct, abilities []Action.AbilityStruct, attacks []Action.AttackStruct, raids []Action.RaidStruct, moves []Action.MoveStruct, bonusAttacks []Action.BonusAttackStruct) error
 }
 
 var _ ActionProcessor = (*Action.Processor)(nil)
@@ -36,41 +62,112 @@ type IPlayerBlocker interface {
 
 var _ IPlayerBlocker = (*PlayerBlocker.Struct)(nil)
 
+type IHub interface {
+	Publish(roomId, subscriberId string, event Event.Interface) error
+	PublishToAll(roomId string, event Event.Interface) error
+}
+
+var _ IHub = (HubPorts.HubInterface)(nil)
+
 type Struct struct {
-	al ActionList
-	ap ActionProcessor
-	pb IPlayerBlocker
+	rooms Room.Rooms
+	al    ActionList
+	ap    ActionProcessor
+	pb    IPlayerBlocker
+	hub   IHub
+
+	lock       sync.Mutex
+	proceeding map[Room.Id]struct{}
+	timers     map[Room.Id]*time.Timer
 }
 
-var _ Interface = (*Struct)(nil)
+var (
+	_ Interface      = (*Struct)(nil)
+	_ IPlanningTimer = (*Struct)(nil)
+)
 
-func New(al ActionList, ap ActionProcessor, pb IPlayerBlocker) *Struct {
-	return &Struct{al, ap, pb}
+func New(rooms Room.Rooms, al ActionList, ap ActionProcessor, pb IPlayerBlocker, hub IHub) *Struct {
+	return &Struct{
+		rooms:      rooms,
+		al:         al,
+		ap:         ap,
+		pb:         pb,
+		hub:        hub,
+		proceeding: make(map[Room.Id]struct{}),
+		timers:     make(map[Room.Id]*time.Timer),
+	}
 }
 
 // Proceed implements Interface.
 func (s *Struct) Proceed(ctx context.Context, roomId string, logger *slog.Logger) error {
-	defer func() {
-		_ = s.al.Reset(Room.Id(roomId))
-	}()
+	return s.proceed(ctx, Room.Id(roomId), logger, false)
+}
+
+// SetRequireAllReady implements Interface.
+func (s *Struct) SetRequireAllReady(roomId string, enabled bool) error {
+	room, found := s.rooms[Room.Id(roomId)]
+	if !found {
+		return fmt.Errorf("ProceedUseCase.SetRequireAllReady: %s %w", "room", ErrNotFound)
+	}
+
+	room.Settings.RequireAllReady = enabled
+
+	return nil
+}
+
+// proceed resolves the turn. Unless forced, it refuses to when the room
+// requires every player to be ready and some are not.
+func (s *Struct) proceed(ctx context.Context, id Room.Id, logger *slog.Logger, force bool) error {
 	logger = logger.With("component", "ProceedUseCase")
+	roomId := string(id)
+
+	room, found := s.rooms[id]
+	if !found {
+		return fmt.Errorf("ProceedUseCase.Proceed: %s %w", "room", ErrNotFound)
+	}
 
-	id := Room.Id(roomId)
+	if room.Settings.RequireAllReady && !force {
+		readiness, err := s.readiness(id, room)
+		if err != nil {
+			return err
+		}
+		if !readiness.AllReady() {
+			return fmt.Errorf("ProceedUseCase.Proceed: %w", ErrNotAllReady)
+		}
+	}
+
+	if err := s.begin(id); err != nil {
+		return err
+	}
+	defer func() {
+		_ = s.al.Reset(id)
+		s.publishReadiness(id, room)
+	}()
 
 	s.pb.Initialize(id)
 	if err := s.pb.UnblockAllChannels(id); err != nil {
+		s.end(id)
 		return err
 	}
 
 	var totalErrors error
+	useActions, err := s.al.GetUseList(id)
+	totalErrors = errors.Join(totalErrors, err)
+	dropActions, err := s.al.GetDropList(id)
+	totalErrors = errors.Join(totalErrors, err)
+	abilityActions, err := s.al.GetAbilityList(id)
+	totalErrors = errors.Join(totalErrors, err)
 	attackActions, err := s.al.GetAttackActionList(id)
 	totalErrors = errors.Join(totalErrors, err)
+	raidActions, err := s.al.GetRaidList(id)
+	totalErrors = errors.Join(totalErrors, err)
 	moveActions, err := s.al.GetMoveActionList(id)
 	totalErrors = errors.Join(totalErrors, err)
 	bonusAttackActions, err := s.al.GetBonusAttackList(id)
 	totalErrors = errors.Join(totalErrors, err)
 
 	if totalErrors != nil {
+		s.end(id)
 		return totalErrors
 	}
 
@@ -83,12 +180,120 @@ func (s *Struct) Proceed(ctx context.Context, roomId string, logger *slog.Logger
 					slog.String("stack", string(debug.Stack())),
 				)
 			}
+
+			s.end(id)
+			if err := s.StartPlanning(id); err != nil {
+				logger.ErrorContext(ctx, "Could not start the next planning phase", slog.Any("Error", err))
+			}
 		}()
 
-		if err := s.ap.Process(Room.Id(roomId), attackActions, moveActions, bonusAttackActions); err != nil {
+		if err := s.ap.Process(Room.Id(roomId), useActions, dropActions, abilityActions, attackActions, raidActions, moveActions, bonusAttackActions); err != nil {
 			logger.ErrorContext(ctx, "Error Processing the Proceed Request", slog.Any("Error", err))
 		}
 	}()
 
 	return nil
 }
+
+// StartPlanning implements IPlanningTimer. It arms the timer of the room and
+// announces the deadline. Rooms without a planning timer are left alone.
+func (s *Struct) StartPlanning(roomId Room.Id) error {
+	room, found := s.rooms[roomId]
+	if !found {
+		return fmt.Errorf("ProceedUseCase.StartPlanning: %s %w", "room", ErrNotFound)
+	}
+
+	seconds := room.Settings.PlanningSeconds
+	if seconds <= 0 {
+		return nil
+	}
+
+	duration := time.Duration(seconds) * time.Second
+	deadline := time.Now().Add(duration)
+
+	s.lock.Lock()
+	if timer, found := s.timers[roomId]; found {
+		timer.Stop()
+	}
+	s.timers[roomId] = time.AfterFunc(duration, func() {
+		s.autoProceed(roomId, "The planning deadline expired", true)
+	})
+	s.lock.Unlock()
+
+	// Nobody may be watching the game yet, the timer runs regardless.
+	if err := s.hub.PublishToAll(string(roomId), Event.PlanningDeadlineStruct{DeadlineUnixMilli: deadline.UnixMilli(), Seconds: seconds}); err != nil {
+		slog.Default().Warn("ProceedUseCase.StartPlanning: Could not announce the deadline", slog.Any("Error", err), slog.String("RoomId", string(roomId)))
+	}
+
+	// Players may have submitted while the previous turn was processed.
+	s.PlayerSubmitted(roomId)
+
+	return nil
+}
+
+// PlayerSubmitted implements IPlanningTimer. The turn proceeds as soon as
+// every player of a room playing with a planning timer has submitted.
+func (s *Struct) PlayerSubmitted(roomId Room.Id) {
+	room, found := s.rooms[roomId]
+	if !found || room.Settings.PlanningSeconds <= 0 || len(room.Players) == 0 {
+		return
+	}
+
+	readiness, err := s.readiness(roomId, room)
+	if err != nil || !readiness.AllReady() {
+		return
+	}
+
+	go s.autoProceed(roomId, "Every player submitted", false)
+}
+
+func (s *Struct) autoProceed(roomId Room.Id, reason string, force bool) {
+	logger := slog.Default().With(slog.String("RoomId", string(roomId)))
+	logger.Info("ProceedUseCase: " + reason)
+
+	if err := s.proceed(context.Background(), roomId, logger, force); err != nil && !errors.Is(err, ErrAlreadyProceeding) {
+		logger.Error("ProceedUseCase: Could not proceed automatically", slog.Any("Error", err))
+	}
+}
+
+func (s *Struct) readiness(roomId Room.Id, room *Room.Room) (*Readiness.Struct, error) {
+	submitted, err := s.al.GetSubmittedPlayers(roomId)
+	if err != nil {
+		return nil, fmt.Errorf("ProceedUseCase: %w", err)
+	}
+	return Readiness.New(room.Players, submitted), nil
+}
+
+// publishReadiness tells the admin, if watching, who is ready for the new turn.
+func (s *Struct) publishReadiness(roomId Room.Id, room *Room.Room) {
+	readiness, err := s.readiness(roomId, room)
+	if err != nil {
+		return
+	}
+	_ = s.hub.Publish(string(roomId), "admin", Event.ReadinessChangedStruct{Struct: readiness})
+}
+
+// begin marks the room as proceeding and stops its planning timer.
+func (s *Struct) begin(roomId Room.Id) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, found := s.proceeding[roomId]; found {
+		return fmt.Errorf("ProceedUseCase.Proceed: %w", ErrAlreadyProceeding)
+	}
+	s.proceeding[roomId] = struct{}{}
+
+	if timer, found := s.timers[roomId]; found {
+		timer.Stop()
+		delete(s.timers, roomId)
+	}
+
+	return nil
+}
+
+func (s *Struct) end(roomId Room.Id) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.proceeding, roomId)
+}
@@ -0,0 +1,71 @@
+package ReachableUseCase
+
+import (
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Movement"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	"fmt"
+)
+
+var ErrNotFound = Failure.New(Failure.NotFound, "not found")
+
+type Interface interface {
+	List(roomId Room.Id, playerId Player.Id) ([]Movement.Position, error)
+}
+
+type Struct struct {
+	rooms Room.Rooms
+}
+
+var _ Interface = (*Struct)(nil)
+
+func New(rooms Room.Rooms) *Struct {
+	return &Struct{rooms}
+}
+
+// List implements Interface. It returns the tiles the player can move to
+// this turn from where it stands, the zones of control of the enemies included.
+func (s *Struct) List(roomId Room.Id, playerId Player.Id) ([]Movement.Position, error) {
+	room, found := s.rooms[roomId]
+	if !found {
+		return nil, fmt.Errorf("ReachableUseCase.List: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[playerId]
+	if !found {
+		return nil, fmt.Errorf("ReachableUseCase.List: %s %w", "player", ErrNotFound)
+	}
+
+	if !room.Map.IsInitialized() {
+		return nil, fmt.Errorf("ReachableUseCase.List: %s %w", "map", ErrNotFound)
+	}
+
+	var stops func(Movement.Position) bool
+	if !player.Class.IgnoresZoneOfControl {
+		stops = func(position Movement.Position) bool {
+			return inZoneOfControl(room.Players, player, position)
+		}
+	}
+
+	return Movement.Reachable(room.Map, Movement.Position{X: player.X, Y: player.Y}, player.Speed(), stops), nil
+}
+
+func inZoneOfControl(players map[Player.Id]*Player.Struct, mover *Player.Struct, position Movement.Position) bool {
+	for _, enemy := range players {
+		if enemy.TeamNumber == mover.TeamNumber || !enemy.Class.ExertsZoneOfControl {
+			continue
+		}
+		if abs(enemy.X-position.X)+abs(enemy.Y-position.Y) <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -1,30 +1,61 @@
 package RoomFactory
 
 import (
+	"ChoHanJi/domain/Class"
 	"ChoHanJi/domain/Item"
 	m "ChoHanJi/domain/Map"
 	r "ChoHanJi/domain/Room"
+	"ChoHanJi/domain/Team"
 	"ChoHanJi/useCases/RoomFactory/ports"
 	"errors"
 	"fmt"
 )
 
+type IClasses interface {
+	Get(name string) (Class.Struct, error)
+}
+
+var _ IClasses = (*Class.Registry)(nil)
+
 type RoomFactory struct {
-	rooms r.Rooms
+	rooms   r.Rooms
+	classes IClasses
 }
 
 var _ ports.UseCaseInterface = (*RoomFactory)(nil)
 
-func NewRoomFactory(rooms r.Rooms) (*RoomFactory, error) {
+func NewRoomFactory(rooms r.Rooms, classes IClasses) (*RoomFactory, error) {
 	if rooms == nil {
 		return nil, errors.New("Room.NewRoomFactory: rooms data is null")
 	}
-	return &RoomFactory{rooms}, nil
+	return &RoomFactory{rooms, classes}, nil
 }
 
-func (f *RoomFactory) Create(width, height int, itemNames string) (r.Id, error) {
-	items := Item.DecodeItems(itemNames)
-	fieldMap, err := m.NewMap(width, height, items)
+func (f *RoomFactory) Create(width, height int, itemSpecs []Item.Spec, settings r.Settings) (r.Id, error) {
+	settings, err := f.resolveClasses(settings)
+	if err != nil {
+		return "", fmt.Errorf("RoomFactory.Create: %w", err)
+	}
+
+	settings.Teams, err = Team.Roster(settings.Teams)
+	if err != nil {
+		return "", fmt.Errorf("RoomFactory.Create: %w", err)
+	}
+	if settings.FreeForAll && settings.TeamSize > 1 {
+		return "", fmt.Errorf("RoomFactory.Create: %w: free-for-all teams have one player, not %d", Team.ErrInvalidTeams, settings.TeamSize)
+	}
+
+	// The map is checked before the items are made, however many are asked for.
+	if err := m.Validate(width, height, Team.Numbers(settings.Teams), Item.Total(itemSpecs), settings.Layout); err != nil {
+		return "", fmt.Errorf("RoomFactory.Create: %w", err)
+	}
+
+	items, err := Item.Create(itemSpecs)
+	if err != nil {
+		return "", fmt.Errorf("RoomFactory.Create: Failed to create the items: %w", err)
+	}
+
+	fieldMap, err := m.NewMap(width, height, Team.Numbers(settings.Teams), items, settings.Scatter, settings.Layout)
 	if err != nil {
 		return "", fmt.Errorf("RoomFactory.Create: Failed to create the map: %w", err)
 	}
@@ -34,9 +65,38 @@ func (f *RoomFactory) Create(width, height int, itemNames string) (r.Id, error)
 		return "", fmt.Errorf("RoomFactory.Create: Failed to create the room %w", err)
 	}
 
+	f.rooms[id].Settings = settings
+
 	for _, val := range items {
 		f.rooms[id].Items[val.Id] = val
 	}
 
 	return id, nil
 }
+
+// resolveClasses replaces the class names or aliases of the restrictions with
+// the names of the classes.
+func (f *RoomFactory) resolveClasses(settings r.Settings) (r.Settings, error) {
+	allowed := make([]string, 0, len(settings.AllowedClasses))
+	for _, name := range settings.AllowedClasses {
+		class, err := f.classes.Get(name)
+		if err != nil {
+			return settings, err
+		}
+		allowed = append(allowed, class.Name)
+	}
+
+	limits := make(map[string]int, len(settings.ClassLimits))
+	for name, limit := range settings.ClassLimits {
+		class, err := f.classes.Get(name)
+		if err != nil {
+			return settings, err
+		}
+		limits[class.Name] = limit
+	}
+
+	settings.AllowedClasses = allowed
+	settings.ClassLimits = limits
+
+	return settings, nil
+}
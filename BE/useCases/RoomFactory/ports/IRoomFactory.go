@@ -1,9 +1,10 @@
 package ports
 
 import (
+	"ChoHanJi/domain/Item"
 	r "ChoHanJi/domain/Room"
 )
 
 type UseCaseInterface interface {
-	Create(width int, height int, items string) (r.Id, error)
+	Create(width int, height int, items []Item.Spec, settings r.Settings) (r.Id, error)
 }
@@ -2,10 +2,12 @@ package StartGameUseCase
 
 import (
 	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
 	"ChoHanJi/domain/Room"
-	"ChoHanJi/driven/sse/SSEHub"
-	"encoding/json"
-	"errors"
+	HubPorts "ChoHanJi/driven/ports"
+	"ChoHanJi/useCases/ProceedUseCase"
+	"fmt"
 )
 
 type IActionList interface {
@@ -13,14 +15,21 @@ type IActionList interface {
 }
 
 type IHub interface {
-	PublishToAll(roomId, messageType, messageBody string) error
+	PublishToAll(roomId string, event Event.Interface) error
+}
+
+type IPlanningTimer interface {
+	StartPlanning(roomId Room.Id) error
 }
 
 var (
-	_ IActionList = (*Action.List)(nil)
-	_ IHub        = (*SSEHub.Struct)(nil)
+	_ IActionList    = (*Action.List)(nil)
+	_ IHub           = (HubPorts.HubInterface)(nil)
+	_ IPlanningTimer = (ProceedUseCase.IPlanningTimer)(nil)
 )
 
+var ErrNotFound = Failure.New(Failure.NotFound, "not found")
+
 type Interface interface {
 	Announce(roomId string) error
 }
@@ -29,19 +38,20 @@ type Struct struct {
 	rooms Room.Rooms
 	list  IActionList
 	hub   IHub
+	timer IPlanningTimer
 }
 
 var _ Interface = (*Struct)(nil)
 
-func New(rooms Room.Rooms, list IActionList, hub IHub) *Struct {
-	return &Struct{rooms, list, hub}
+func New(rooms Room.Rooms, list IActionList, hub IHub, timer IPlanningTimer) *Struct {
+	return &Struct{rooms, list, hub, timer}
 }
 
 // Announce implements IStartGameUseCase.
 func (s *Struct) Announce(roomId string) error {
 	room, found := s.rooms[Room.Id(roomId)]
 	if !found {
-		return errors.New("game not found")
+		return fmt.Errorf("StartGameUseCase.Announce: %s %w", "room", ErrNotFound)
 	}
 
 	height, err := room.Map.GetMapHeight()
@@ -54,24 +64,15 @@ func (s *Struct) Announce(roomId string) error {
 		return err
 	}
 
-	messageBody := MessageBody{roomId, height, width}
+	room.Started = true
 
-	msg, err := json.Marshal(messageBody)
-	if err != nil {
-		return errors.New("failed to send the game start message")
-	}
+	messageBody := Event.GameStartStruct{RoomId: roomId, MapHeight: height, MapWidth: width}
 
-	if err := s.hub.PublishToAll(roomId, "GameStart", string(msg)); err != nil {
+	if err := s.hub.PublishToAll(roomId, messageBody); err != nil {
 		return err
 	}
 
 	s.list.StartGame(Room.Id(roomId))
 
-	return nil
-}
-
-type MessageBody struct {
-	RoomId    string `json:"RoomId"`
-	MapHeight int    `json:"MapHeight"`
-	MapWeidth int    `json:"MapWidth"`
+	return s.timer.StartPlanning(Room.Id(roomId))
 }
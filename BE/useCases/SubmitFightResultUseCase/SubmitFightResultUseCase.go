@@ -1,14 +1,15 @@
 package SubmitFightResultUseCase
 
 import (
+	"ChoHanJi/domain/Death"
+	"ChoHanJi/domain/Event"
 	"ChoHanJi/domain/Fight"
 	"ChoHanJi/domain/Player"
 	"ChoHanJi/domain/PlayerBlocker"
 	"ChoHanJi/domain/Room"
-	"encoding/json"
 	"fmt"
 
-	"ChoHanJi/driven/sse/SSEHub"
+	HubPorts "ChoHanJi/driven/ports"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -26,24 +27,33 @@ type IPlayerBlocker interface {
 
 var _ IPlayerBlocker = (*PlayerBlocker.Struct)(nil)
 
+type IDeathList interface {
+	PronounceDead(roomId Room.Id, playerId Player.Id)
+}
+
+var _ IDeathList = (*Death.List)(nil)
+
 type IHub interface {
-	Publish(roomId, subscriberId, messageType, messageBody string) error
+	Publish(roomId, subscriberId string, event Event.Interface) error
+	PublishToSpectators(roomId string, event Event.Interface) error
 }
 
-var _ IHub = (*SSEHub.Struct)(nil)
+var _ IHub = (HubPorts.HubInterface)(nil)
 var _ IFights = (*Fight.CurrentFights)(nil)
 
 type Struct struct {
 	fights    IFights
 	blocker   IPlayerBlocker
+	deaths    IDeathList
 	hub       IHub
 	validator *validator.Validate
 }
 
-func New(fights IFights, blocker IPlayerBlocker, hub IHub, validator *validator.Validate) *Struct {
+func New(fights IFights, blocker IPlayerBlocker, deaths IDeathList, hub IHub, validator *validator.Validate) *Struct {
 	return &Struct{
 		fights:    fights,
 		blocker:   blocker,
+		deaths:    deaths,
 		hub:       hub,
 		validator: validator,
 	}
@@ -67,20 +77,28 @@ func (s *Struct) Submit(roomId Room.Id, fightId Fight.Id, submitterId Player.Id,
 		return nil
 	}
 
+	// The loser dies before the processor resumes, so it sees the death.
+	loserId := fight.DefenderId
+	if fight.WinnerId == fight.DefenderId {
+		loserId = fight.AttackerId
+	}
+	s.deaths.PronounceDead(roomId, loserId)
+
 	// Unblock both participants so the processor can continue.
 	_ = s.blocker.Unblock(roomId, fight.AttackerId)
 	_ = s.blocker.Unblock(roomId, fight.DefenderId)
 
-	msg, err := json.Marshal(fight)
-	if err != nil {
+	msg := Event.FightResultStruct{Struct: fight}
+
+	if err := s.hub.Publish(string(roomId), string(fight.AttackerId), msg); err != nil {
 		return fmt.Errorf("SubmitFightResultUseCase.Submit: %w", err)
 	}
 
-	if err := s.hub.Publish(string(roomId), string(fight.AttackerId), "FightResult", string(msg)); err != nil {
+	if err := s.hub.Publish(string(roomId), string(fight.DefenderId), msg); err != nil {
 		return fmt.Errorf("SubmitFightResultUseCase.Submit: %w", err)
 	}
 
-	if err := s.hub.Publish(string(roomId), string(fight.DefenderId), "FightResult", string(msg)); err != nil {
+	if err := s.hub.PublishToSpectators(string(roomId), msg); err != nil {
 		return fmt.Errorf("SubmitFightResultUseCase.Submit: %w", err)
 	}
 
@@ -1,45 +1,99 @@
 package SubmitMoveUseCase
 
 import (
+	"ChoHanJi/domain/Ability"
 	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Class"
+	"ChoHanJi/domain/Cooldown"
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Movement"
 	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Readiness"
 	"ChoHanJi/domain/Room"
-	"ChoHanJi/driven/sse/SSEHub"
+	"ChoHanJi/domain/Team"
+	HubPorts "ChoHanJi/driven/ports"
+	"ChoHanJi/useCases/ProceedUseCase"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/go-playground/validator/v10"
 )
 
-var ErrWrongInput = errors.New("input format wrong")
+var (
+	ErrNotFound         = Failure.New(Failure.NotFound, "not found")
+	ErrWrongInput       = Failure.New(Failure.Invalid, "input format wrong")
+	ErrActionNotAllowed = Failure.New(Failure.Invalid, "the class of the player cannot do this action")
+	ErrOnCooldown       = Failure.New(Failure.Conflict, "the ability is on cooldown")
+	ErrNotCarried       = Failure.New(Failure.Invalid, "the player does not carry the item")
+	ErrOutOfRange       = Failure.New(Failure.Invalid, "the target is out of range")
+	ErrRespawning       = Failure.New(Failure.Conflict, "the player is waiting to respawn")
+	ErrUnknownAbility   = Failure.New(Failure.Invalid, "unknown ability")
+	ErrNeedsTarget      = Failure.New(Failure.Invalid, "the ability needs a target")
+	ErrNotUsable        = Failure.New(Failure.Invalid, "the item cannot be used")
+	ErrNoEnemyChest     = Failure.New(Failure.Invalid, "no enemy chest to raid")
+)
+
+// classActions names the actions the class of the player has to allow.
+var classActions = map[Action.Enum]string{
+	Action.Move:        Class.Move,
+	Action.Attack:      Class.Attack,
+	Action.BonusAttack: Class.BonusAttack,
+	Action.Raid:        Class.Raid,
+	Action.Ability:     Class.UseAbility,
+	Action.Drop:        Class.Drop,
+	Action.Use:         Class.Use,
+}
 
 type Interface interface {
 	Submit(roomId Room.Id, actionType Action.Enum, msg []byte) error
 }
 
 type IActionList interface {
-	SubmitMoveAction(roomId Room.Id, x, y, prevX, prevY int, id Player.Id) error
+	SubmitMoveAction(roomId Room.Id, x, y, prevX, prevY int, path []Movement.Position, id Player.Id, extend bool, check func(Action.MoveStruct) error) error
 	SubmitAttackAction(roomId Room.Id, attackerId, defenderId Player.Id) error
 	SubmitBonusAttackAction(roomId Room.Id, x, y int, attackerId Player.Id) error
+	SubmitSkipAction(roomId Room.Id, id Player.Id) error
+	SubmitAbilityAction(roomId Room.Id, ability Action.AbilityStruct) error
+	SubmitDropAction(roomId Room.Id, drop Action.ItemActionStruct) error
+	SubmitUseAction(roomId Room.Id, use Action.ItemActionStruct) error
+	SubmitRaidAction(roomId Room.Id, raid Action.RaidStruct) error
+	WithdrawAction(roomId Room.Id, id Player.Id) error
+	GetSubmittedPlayers(roomId Room.Id) ([]Player.Id, error)
+}
+
+type IPlanningTimer interface {
+	PlayerSubmitted(roomId Room.Id)
+}
+
+var _ IPlanningTimer = (ProceedUseCase.IPlanningTimer)(nil)
+
+type ICooldowns interface {
+	Remaining(roomId Room.Id, playerId Player.Id, ability Ability.Enum) int
 }
 
+var _ ICooldowns = (*Cooldown.List)(nil)
+
 type IHub interface {
-	Publish(roomId, subscriberId, messageType, messageBody string) error
+	Publish(roomId, subscriberId string, event Event.Interface) error
 }
 
-var _ IHub = (*SSEHub.Struct)(nil)
+var _ IHub = (HubPorts.HubInterface)(nil)
 
 var _ IActionList = (*Action.List)(nil)
 
 type Struct struct {
+	rooms     Room.Rooms
 	al        IActionList
 	hub       IHub
+	timer     IPlanningTimer
+	cooldowns ICooldowns
 	validator *validator.Validate
 }
 
-func New(validator *validator.Validate, hub IHub, actionList IActionList) *Struct {
-	return &Struct{actionList, hub, validator}
+func New(rooms Room.Rooms, validator *validator.Validate, hub IHub, actionList IActionList, timer IPlanningTimer, cooldowns ICooldowns) *Struct {
+	return &Struct{rooms, actionList, hub, timer, cooldowns, validator}
 }
 
 var _ Interface = (*Struct)(nil)
@@ -52,59 +106,311 @@ func (s *Struct) Submit(roomId Room.Id, actionType Action.Enum, msg []byte) erro
 	case Action.Attack:
 		var attackAction Action.AttackStruct
 		if err := json.Unmarshal(msg, &attackAction); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		// Validate
 		if err := s.validator.Struct(attackAction); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		id = attackAction.AttackerId
+		if err := s.checkClassAllows(roomId, id, actionType); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
 		if err := s.al.SubmitAttackAction(roomId, attackAction.AttackerId, attackAction.DefenderId); err != nil {
 			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
 		}
+	case Action.Raid:
+		var raid Action.RaidStruct
+		if err := json.Unmarshal(msg, &raid); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		// Validate
+		if err := s.validator.Struct(raid); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		id = raid.Id
+		if err := s.checkClassAllows(roomId, id, actionType); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		if err := s.checkRaid(roomId, raid); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		if err := s.al.SubmitRaidAction(roomId, raid); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
+		}
 	case Action.Move:
 		var move Action.MoveStruct
 		if err := json.Unmarshal(msg, &move); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		// Validate
 		if err := s.validator.Struct(move); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		id = move.Id
-		if err := s.al.SubmitMoveAction(roomId, move.X, move.Y, move.PrevX, move.PrevY, move.Id); err != nil {
+		if err := s.checkClassAllows(roomId, id, actionType); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		checkPath := func(played Action.MoveStruct) error {
+			if err := s.validatePath(roomId, played); err != nil {
+				return fmt.Errorf("%w: %w", ErrWrongInput, err)
+			}
+			return nil
+		}
+		if err := s.al.SubmitMoveAction(roomId, move.X, move.Y, move.PrevX, move.PrevY, move.Path, move.Id, move.Extend, checkPath); err != nil {
 			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
 		}
 	case Action.BonusAttack:
 		var action Action.BonusAttackStruct
 		if err := json.Unmarshal(msg, &action); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		// Validate
 		if err := s.validator.Struct(action); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		id = action.Id
+		if err := s.checkClassAllows(roomId, id, actionType); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
 		if err := s.al.SubmitBonusAttackAction(roomId, action.X, action.Y, action.Id); err != nil {
 			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
 		}
+	case Action.Ability:
+		var ability Action.AbilityStruct
+		if err := json.Unmarshal(msg, &ability); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		// Validate
+		if err := s.validator.Struct(ability); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		id = ability.Id
+		if err := s.checkClassAllows(roomId, id, actionType); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		if err := s.checkAbility(roomId, ability); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		if err := s.al.SubmitAbilityAction(roomId, ability); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
+		}
+	case Action.Drop, Action.Use:
+		var action Action.ItemActionStruct
+		if err := json.Unmarshal(msg, &action); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		// Validate
+		if err := s.validator.Struct(action); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		id = action.Id
+		if err := s.checkClassAllows(roomId, id, actionType); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		if err := s.checkItem(roomId, action, actionType == Action.Use); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		submit := s.al.SubmitDropAction
+		if actionType == Action.Use {
+			submit = s.al.SubmitUseAction
+		}
+		if err := submit(roomId, action); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
+		}
 	case Action.Skip:
 		var skip Action.SkipStruct
 		if err := json.Unmarshal(msg, &skip); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		// Validate
 		if err := s.validator.Struct(skip); err != nil {
-			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %v", ErrWrongInput, err)
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
 		}
 		id = skip.Id
+		if err := s.al.SubmitSkipAction(roomId, skip.Id); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
+		}
+	case Action.Withdraw:
+		var withdraw Action.WithdrawStruct
+		if err := json.Unmarshal(msg, &withdraw); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		// Validate
+		if err := s.validator.Struct(withdraw); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w: %w", ErrWrongInput, err)
+		}
+		if err := s.al.WithdrawAction(roomId, withdraw.Id); err != nil {
+			return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
+		}
+		_ = s.hub.Publish(string(roomId), "admin", Event.ActionWithdrawnStruct{PlayerId: withdraw.Id})
+		s.publishReadiness(roomId)
+		return nil
 	default:
 		return fmt.Errorf("SubmitMoveUseCase.Submit: %w", ErrWrongInput)
 	}
 
-	if err := s.hub.Publish(string(roomId), "admin", "PlayerIsReady", string(id)); err != nil {
-		return fmt.Errorf("SubmitMoveUseCase.Submit: %w", err)
+	s.timer.PlayerSubmitted(roomId)
+
+	// The action is already recorded, and games running on a planning timer
+	// may have no admin watching, so a missed notification is not an error.
+	_ = s.hub.Publish(string(roomId), "admin", Event.PlayerIsReadyStruct{PlayerId: id})
+	s.publishReadiness(roomId)
+
+	return nil
+}
+
+// checkClassAllows refuses the actions the class of the player does not list.
+func (s *Struct) checkClassAllows(roomId Room.Id, id Player.Id, actionType Action.Enum) error {
+	room, found := s.rooms[roomId]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkClassAllows: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[id]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkClassAllows: %s %w", "player", ErrNotFound)
+	}
+	if player.IsRespawning() {
+		return ErrRespawning
+	}
+
+	if !player.Class.Allows(classActions[actionType]) {
+		return fmt.Errorf("%w: %s cannot %s", ErrActionNotAllowed, player.Class.Name, classActions[actionType])
+	}
+
+	return nil
+}
+
+// checkAbility refuses the abilities the class of the player lacks, those
+// missing their target and those still on cooldown.
+func (s *Struct) checkAbility(roomId Room.Id, ability Action.AbilityStruct) error {
+	room, found := s.rooms[roomId]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkAbility: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[ability.Id]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkAbility: %s %w", "player", ErrNotFound)
+	}
+	if player.IsRespawning() {
+		return ErrRespawning
+	}
+
+	definition, found := Ability.Get(ability.Ability)
+	if !found {
+		return fmt.Errorf("%w: %q", ErrUnknownAbility, ability.Ability)
+	}
+	if !player.Class.HasAbility(ability.Ability) {
+		return fmt.Errorf("%w: %s cannot use %s", ErrActionNotAllowed, player.Class.Name, ability.Ability)
+	}
+	if definition.NeedsTarget && ability.TargetId == "" {
+		return fmt.Errorf("%w: %s", ErrNeedsTarget, ability.Ability)
+	}
+	if turns := s.cooldowns.Remaining(roomId, ability.Id, ability.Ability); turns > 0 {
+		return fmt.Errorf("%w: %d more turns", ErrOnCooldown, turns)
+	}
+
+	return nil
+}
+
+// checkItem refuses to drop or use an item the player does not carry, and to
+// use one that is not consumable.
+func (s *Struct) checkItem(roomId Room.Id, action Action.ItemActionStruct, use bool) error {
+	room, found := s.rooms[roomId]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkItem: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[action.Id]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkItem: %s %w", "player", ErrNotFound)
+	}
+	if player.IsRespawning() {
+		return ErrRespawning
+	}
+
+	item := player.Find(action.ItemId)
+	if item == nil {
+		return fmt.Errorf("%w: %s", ErrNotCarried, action.ItemId)
+	}
+	if use && !item.IsConsumable() {
+		return fmt.Errorf("%w: %s", ErrNotUsable, item.Name)
+	}
+
+	return nil
+}
+
+// checkRaid refuses the raids of the players waiting to respawn, and those
+// of the chest of the team of the raider, of a team not playing, or of a
+// chest out of the range of the raider.
+func (s *Struct) checkRaid(roomId Room.Id, raid Action.RaidStruct) error {
+	room, found := s.rooms[roomId]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkRaid: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[raid.Id]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.checkRaid: %s %w", "player", ErrNotFound)
+	}
+	if player.IsRespawning() {
+		return ErrRespawning
+	}
+
+	team := Team.Enum(raid.Team)
+	if raid.Team == player.TeamNumber || !slices.Contains(room.Map.Teams(), team) {
+		return fmt.Errorf("%w: team %d", ErrNoEnemyChest, raid.Team)
+	}
+
+	chestX, chestY := room.Map.GetTeamTreasureChestLocation(team)
+	if abs(player.X-chestX)+abs(player.Y-chestY) > player.Class.Range {
+		return fmt.Errorf("%w: the chest of team %d", ErrOutOfRange, raid.Team)
 	}
 
 	return nil
 }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// validatePath checks the route of the move on the map of the room, the
+// speed of the player included. An extended move is checked whole, from
+// where its first part started.
+func (s *Struct) validatePath(roomId Room.Id, move Action.MoveStruct) error {
+	room, found := s.rooms[roomId]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.validatePath: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[move.Id]
+	if !found {
+		return fmt.Errorf("SubmitMoveUseCase.validatePath: %s %w", "player", ErrNotFound)
+	}
+
+	if n := len(move.Path); n > 0 && (move.Path[n-1].X != move.X || move.Path[n-1].Y != move.Y) {
+		return fmt.Errorf("%w: the path does not end at the destination", Movement.ErrInvalidPath)
+	}
+
+	return Movement.Validate(room.Map, Movement.Position{X: move.PrevX, Y: move.PrevY}, move.Route(), player.Speed())
+}
+
+// publishReadiness sends the admin the readiness of the room, if the admin is watching.
+func (s *Struct) publishReadiness(roomId Room.Id) {
+	room, found := s.rooms[roomId]
+	if !found {
+		return
+	}
+
+	submitted, err := s.al.GetSubmittedPlayers(roomId)
+	if err != nil {
+		return
+	}
+
+	_ = s.hub.Publish(string(roomId), "admin", Event.ReadinessChangedStruct{Struct: Readiness.New(room.Players, submitted)})
+}
@@ -0,0 +1,285 @@
+package WebSocketUseCase
+
+import (
+	"ChoHanJi/domain/Action"
+	"ChoHanJi/domain/Event"
+	"ChoHanJi/domain/Failure"
+	"ChoHanJi/domain/Fight"
+	"ChoHanJi/domain/Player"
+	"ChoHanJi/domain/Room"
+	HubPorts "ChoHanJi/driven/ports"
+	"ChoHanJi/infrastructure/Logging"
+	"ChoHanJi/useCases/GameStatus"
+	"ChoHanJi/useCases/SubmitFightResultUseCase"
+	"ChoHanJi/useCases/SubmitMoveUseCase"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrNotFound     = Failure.New(Failure.NotFound, "not found")
+	ErrWrongCommand = Failure.New(Failure.Invalid, "command format wrong")
+	ErrNotYours     = errors.New("the command is sent for another player")
+	ErrReadOnly     = errors.New("the admin socket only listens, the admin acts through the API")
+)
+
+// Connection is a bidirectional message stream to a single client.
+type Connection interface {
+	Read(ctx context.Context) ([]byte, error)
+	Write(ctx context.Context, msg []byte) error
+}
+
+type Interface interface {
+	ConnectAndListen(ctx context.Context, conn Connection, roomId string, playerId string) error
+}
+
+type ILobbyHub interface {
+	Subscribe(roomId, subscriberId string) <-chan []byte
+	Unsubscribe(roomId, subscriberId string) error
+	Publish(roomId, subscriberId string, event Event.Interface) error
+}
+
+type IGameHub interface {
+	Subscribe(roomId, subscriberId string) <-chan []byte
+	Unsubscribe(roomId, subscriberId string) error
+}
+
+type IGameSnapshot interface {
+	GetConnectedMessage(roomId string, subscriberId string) (*Event.ConnectionStruct, error)
+}
+
+var (
+	_ ILobbyHub     = (HubPorts.HubInterface)(nil)
+	_ IGameHub      = (HubPorts.HubInterface)(nil)
+	_ IGameSnapshot = (*GameStatus.UseCase)(nil)
+)
+
+// Command is the upstream envelope sent by the client.
+type Command struct {
+	CommandType string          `json:"CommandType" validate:"required,oneof=Move Attack BonusAttack Ability Drop Use Raid Skip Withdraw FightResult"`
+	Command     json.RawMessage `json:"Command" validate:"required"`
+}
+
+// sender holds the fields naming the player a command is sent for, the
+// attacks naming their attacker and the fight results their submitter.
+type sender struct {
+	Id          Player.Id `json:"Id"`
+	AttackerId  Player.Id `json:"AttackerId"`
+	SubmitterId Player.Id `json:"SubmitterId"`
+}
+
+var actionTypes = map[string]Action.Enum{
+	"Move":        Action.Move,
+	"Attack":      Action.Attack,
+	"BonusAttack": Action.BonusAttack,
+	"Ability":     Action.Ability,
+	"Drop":        Action.Drop,
+	"Use":         Action.Use,
+	"Raid":        Action.Raid,
+	"Skip":        Action.Skip,
+	"Withdraw":    Action.Withdraw,
+}
+
+type Struct struct {
+	rooms       Room.Rooms
+	lobbyHub    ILobbyHub
+	gameHub     IGameHub
+	snapshot    IGameSnapshot
+	submitMove  SubmitMoveUseCase.Interface
+	submitFight SubmitFightResultUseCase.Interface
+	validator   *validator.Validate
+}
+
+var _ Interface = (*Struct)(nil)
+
+func New(
+	rooms Room.Rooms,
+	lobbyHub ILobbyHub,
+	gameHub IGameHub,
+	snapshot IGameSnapshot,
+	submitMove SubmitMoveUseCase.Interface,
+	submitFight SubmitFightResultUseCase.Interface,
+	validator *validator.Validate,
+) *Struct {
+	return &Struct{rooms, lobbyHub, gameHub, snapshot, submitMove, submitFight, validator}
+}
+
+// ConnectAndListen implements Interface.
+func (s *Struct) ConnectAndListen(ctx context.Context, conn Connection, roomId string, playerId string) error {
+	logger, _ := Logging.RetrieveLogger(ctx)
+
+	room, found := s.rooms[Room.Id(roomId)]
+	if !found {
+		return fmt.Errorf("WebSocketUseCase.ConnectAndListen: %s %w", "room", ErrNotFound)
+	}
+
+	player, found := room.Players[Player.Id(playerId)]
+	if playerId != "admin" && !found {
+		return fmt.Errorf("WebSocketUseCase.ConnectAndListen: %s %w", "player", ErrNotFound)
+	}
+
+	lobby := s.lobbyHub.Subscribe(roomId, playerId)
+	defer func() {
+		if err := s.lobbyHub.Unsubscribe(roomId, playerId); err != nil {
+			logger.Error("WebSocketUseCase.ConnectAndListen:Error Unsubscribing from the lobby", slog.Any("Error", err))
+		}
+	}()
+
+	game := s.gameHub.Subscribe(roomId, playerId)
+	defer func() {
+		if err := s.gameHub.Unsubscribe(roomId, playerId); err != nil {
+			logger.Error("WebSocketUseCase.ConnectAndListen:Error Unsubscribing from the game", slog.Any("Error", err))
+		}
+	}()
+
+	msgBody, err := s.snapshot.GetConnectedMessage(roomId, playerId)
+	if err != nil {
+		return err
+	}
+
+	connectedMessage, err := Event.Encode(msgBody)
+	if err != nil {
+		return err
+	}
+
+	ping, err := Event.Encode(Event.PingStruct{})
+	if err != nil {
+		return err
+	}
+
+	if err := conn.Write(ctx, connectedMessage); err != nil {
+		logger.ErrorContext(ctx, "Could not send connected message")
+		return fmt.Errorf("could not write message, %s", connectedMessage)
+	}
+
+	if player != nil {
+		if err := room.Map.PlacePlayer(player); err != nil {
+			return fmt.Errorf("WebSocketUseCase.ConnectAndListen: Could not place the player: %w", err)
+		}
+
+		if err := s.lobbyHub.Publish(roomId, "admin", Event.PlayerConnectedStruct{Id: player.Id, Name: player.Name, Team: player.TeamNumber}); err != nil {
+			logger.WarnContext(ctx, "WebSocketUseCase.ConnectAndListen: Could not announce player connected message", slog.Any("Error", err))
+		}
+		if err := s.lobbyHub.Publish(roomId, "admin", Event.TeamRostersStruct{Teams: room.Rosters()}); err != nil {
+			logger.WarnContext(ctx, "WebSocketUseCase.ConnectAndListen: Could not publish the team rosters", slog.Any("Error", err))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	replies := make(chan []byte, 16)
+	go s.readCommands(ctx, cancel, conn, roomId, playerId, replies)
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		var msg []byte
+		select {
+		case message, ok := <-lobby:
+			if !ok {
+				logger.ErrorContext(ctx, fmt.Sprintf("WebSocketUseCase.ConnectAndListen: Could not receive the lobby message in the room, %s", roomId))
+				return nil
+			}
+			msg = message
+		case message, ok := <-game:
+			if !ok {
+				logger.ErrorContext(ctx, fmt.Sprintf("WebSocketUseCase.ConnectAndListen: Could not receive the game message in the room, %s", roomId))
+				return nil
+			}
+			msg = message
+		case reply := <-replies:
+			msg = reply
+		case <-ticker.C:
+			msg = ping
+		case <-ctx.Done():
+			return nil
+		}
+
+		if err := conn.Write(ctx, msg); err != nil {
+			logger.ErrorContext(ctx, "Could not send message", slog.Any("Error", err))
+			return nil
+		}
+	}
+}
+
+func (s *Struct) readCommands(ctx context.Context, cancel context.CancelFunc, conn Connection, roomId, playerId string, replies chan<- []byte) {
+	defer cancel()
+
+	logger, _ := Logging.RetrieveLogger(ctx)
+
+	for {
+		raw, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		if err := s.handle(Room.Id(roomId), Player.Id(playerId), raw); err != nil {
+			logger.ErrorContext(ctx, "WebSocketUseCase.readCommands: Could not handle the command", slog.Any("Error", err), slog.String("PlayerId", playerId))
+
+			body, err := Event.Encode(Event.CommandRejectedStruct{Reason: err.Error()})
+			if err != nil {
+				continue
+			}
+
+			select {
+			case replies <- body:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// handle submits the command of the player the connection belongs to. A
+// connection only ever acts for its own player, and the admin, playing no
+// character, sends none.
+func (s *Struct) handle(roomId Room.Id, playerId Player.Id, raw []byte) error {
+	if playerId == "admin" {
+		return fmt.Errorf("WebSocketUseCase.handle: %w", ErrReadOnly)
+	}
+
+	var command Command
+	if err := json.Unmarshal(raw, &command); err != nil {
+		return fmt.Errorf("WebSocketUseCase.handle: %w: %v", ErrWrongCommand, err)
+	}
+	if err := s.validator.Struct(command); err != nil {
+		return fmt.Errorf("WebSocketUseCase.handle: %w: %v", ErrWrongCommand, err)
+	}
+
+	var from sender
+	if err := json.Unmarshal(command.Command, &from); err != nil {
+		return fmt.Errorf("WebSocketUseCase.handle: %w: %v", ErrWrongCommand, err)
+	}
+	id := from.Id
+	switch command.CommandType {
+	case "Attack":
+		id = from.AttackerId
+	case "FightResult":
+		id = from.SubmitterId
+	}
+	if id != playerId {
+		return fmt.Errorf("WebSocketUseCase.handle: %w: %s", ErrNotYours, id)
+	}
+
+	if actionType, found := actionTypes[command.CommandType]; found {
+		return s.submitMove.Submit(roomId, actionType, command.Command)
+	}
+
+	var req SubmitFightResultUseCase.Request
+	if err := json.Unmarshal(command.Command, &req); err != nil {
+		return fmt.Errorf("WebSocketUseCase.handle: %w: %v", ErrWrongCommand, err)
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return fmt.Errorf("WebSocketUseCase.handle: %w: %v", ErrWrongCommand, err)
+	}
+
+	return s.submitFight.Submit(roomId, Fight.Id(req.FightId), Player.Id(req.SubmitterId), Player.Id(req.WinnerId))
+}
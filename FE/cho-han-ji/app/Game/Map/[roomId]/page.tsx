@@ -13,6 +13,12 @@ import { CheckCircle, Circle, Package, Flag as FlagIcon } from "lucide-react";
 import Player from "@/model/Player";
 import { Flag, Teams } from "@/model/Tile";
 import Change from "@/model/Change";
+import { ActionWithdrawnEvent, ConnectionEvent, PlayerIsReadyEvent, ReadinessChangedEvent, Team } from "@/model/generated/Events";
+
+const defaultTeams: Team[] = [
+  { Number: Teams.TEAM1, Name: "Team 1", Color: "" },
+  { Number: Teams.TEAM2, Name: "Team 2", Color: "" },
+];
 
 export default function Page({ params }: { params: Promise<{ roomId: string }> }) {
   const esRef = useRef<EventSource | null>(null);
@@ -21,12 +27,10 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
   const [renderedGrid, setRenderedGrid] = useState<RenderedGrid | null>();
   const [players, setPlayers] = useState<Player[]>([]);
   const [readyPlayers, setReadyPlayers] = useState<Set<string>>(new Set());
-  const [chestItems, setChestItems] = useState<Record<Teams, Record<string, number>>>({
-    [Teams.Neutral]: {},
-    [Teams.TEAM1]: {},
-    [Teams.TEAM2]: {},
-  });
+  const [chestItems, setChestItems] = useState<Partial<Record<Teams, Record<string, number>>>>({});
+  const [teams, setTeams] = useState<Team[]>(defaultTeams);
   const [isSubmitting, setIsSubmitting] = useState(false);
+  const [requireAllReady, setRequireAllReady] = useState(false);
   const { roomId } = use(params);
 
   const grid = renderedGrid ?? [];
@@ -108,11 +112,18 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
           setRenderedGrid(engine.RenderAll());
           refreshChestItems();
           setPlayers(msgBody.Players ?? []);
-          setReadyPlayers(new Set());
+          const connectedTeams = (data.Message as ConnectionEvent).Teams;
+          setTeams(connectedTeams?.length ? connectedTeams : defaultTeams);
+          setReadyPlayers(new Set((data.Message as ConnectionEvent).Readiness?.Ready ?? []));
+        }
+
+        if (baseMessage.MessageType === "ReadinessChanged" && data.Message) {
+          setReadyPlayers(new Set((data.Message as ReadinessChangedEvent).Ready ?? []));
         }
 
-        if (baseMessage.MessageType === "PlayerIsReady" && typeof data.Message === "string") {
-          const playerId = data.Message;
+        if (baseMessage.MessageType === "PlayerIsReady" && data.Message) {
+          const playerId =
+            typeof data.Message === "string" ? data.Message : (data.Message as PlayerIsReadyEvent).PlayerId;
           setReadyPlayers((prev) => {
             const next = new Set(prev);
             next.add(playerId);
@@ -120,6 +131,15 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
           });
         }
 
+        if (baseMessage.MessageType === "ActionWithdrawn" && data.Message) {
+          const playerId = (data.Message as ActionWithdrawnEvent).PlayerId;
+          setReadyPlayers((prev) => {
+            const next = new Set(prev);
+            next.delete(playerId);
+            return next;
+          });
+        }
+
         if (baseMessage.MessageType === "Update") {
           handleUpdateMessage(data.Message);
         }
@@ -165,11 +185,34 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
     }
   }, [roomId]);
 
-  const teamPlayers = useMemo(() => {
-    const team1 = players.filter((player) => player.Team === Teams.TEAM1);
-    const team2 = players.filter((player) => player.Team === Teams.TEAM2);
+  const handleRequireAllReady = useCallback(
+    async (enabled: boolean) => {
+      try {
+        const res = await fetch(
+          `${process.env.NEXT_PUBLIC_API_BASE_URL}api/game/requireAllReady?roomId=${roomId}`,
+          {
+            method: "POST",
+            body: JSON.stringify({ Enabled: enabled }),
+          }
+        );
+
+        if (!res.ok) {
+          throw new Error(`Update failed: ${res.status}`);
+        }
+        setRequireAllReady(enabled);
+      } catch (err) {
+        console.error(err);
+      }
+    },
+    [roomId]
+  );
 
-    return { team1, team2 };
+  const teamPlayers = useMemo(() => {
+    const byTeam: Partial<Record<Teams, Player[]>> = {};
+    for (const player of players) {
+      (byTeam[player.Team] ??= []).push(player);
+    }
+    return byTeam;
   }, [players]);
 
   const renderChestItems = (team: Teams, title: string) => {
@@ -224,6 +267,15 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
         <Button disabled={!isSubmitting} className="w-fit" onClick={handleSubmit}>
           {isSubmitting ? "Submitting..." : "Submit"}
         </Button>
+        <label className="flex items-center gap-2 text-sm text-muted-foreground">
+          <input
+            type="checkbox"
+            className="h-4 w-4"
+            checked={requireAllReady}
+            onChange={(e) => handleRequireAllReady(e.target.checked)}
+          />
+          Proceed only when all ready
+        </label>
       </CardHeader>
       <CardContent className="space-y-6">
         <div className="flex flex-col gap-6 xl:flex-row xl:items-start">
@@ -261,8 +313,11 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
           <div className="flex min-w-[16rem] flex-1 flex-col gap-3 xl:max-w-xs">
             <div className="text-sm font-semibold text-foreground">Players</div>
             <div className="flex flex-col gap-3 sm:flex-row sm:gap-4 xl:flex-col">
-              {renderTeam(Teams.TEAM1, "Team 1", teamPlayers.team1)}
-              {renderTeam(Teams.TEAM2, "Team 2", teamPlayers.team2)}
+              {teams.map((team) => (
+                <div key={team.Number} className="flex-1">
+                  {renderTeam(team.Number, team.Name, teamPlayers[team.Number as Teams] ?? [])}
+                </div>
+              ))}
             </div>
           </div>
 
@@ -270,8 +325,11 @@ export default function Page({ params }: { params: Promise<{ roomId: string }> }
           <div className="flex min-w-[16rem] flex-1 flex-col gap-3 xl:max-w-xs">
             <div className="text-sm font-semibold text-foreground">Chest Items</div>
             <div className="flex flex-col gap-3 sm:flex-row sm:gap-4 xl:flex-col">
-              {renderChestItems(Teams.TEAM1, "Team 1 Chest")}
-              {renderChestItems(Teams.TEAM2, "Team 2 Chest")}
+              {teams.map((team) => (
+                <div key={team.Number} className="flex-1">
+                  {renderChestItems(team.Number, `${team.Name} Chest`)}
+                </div>
+              ))}
             </div>
           </div>
         </div>
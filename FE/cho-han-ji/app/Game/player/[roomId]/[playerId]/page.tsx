@@ -15,6 +15,8 @@ import { Flag } from "@/model/Tile";
 import { Flag as FlagIcon, Package } from "lucide-react";
 import Change from "@/model/Change";
 import { Button } from "@/components/ui/button";
+import { PlanningDeadlineEvent } from "@/model/generated/Events";
+import { describeProblem } from "@/model/Problem";
 
 type RenderedGrid = ReturnType<Engine["RenderAll"]>;
 
@@ -42,6 +44,17 @@ export default function Page({
   const [hasMoved, setHasMoved] = useState(false);
   const [hasAttacked, setHasAttacked] = useState(false);
   const [hasSkipped, setHasSkipped] = useState(false);
+  const [planningDeadline, setPlanningDeadline] = useState<number | null>(null);
+  const [secondsLeft, setSecondsLeft] = useState<number | null>(null);
+
+  useEffect(() => {
+    if (planningDeadline === null) return;
+
+    const tick = () => setSecondsLeft(Math.max(0, Math.ceil((planningDeadline - Date.now()) / 1000)));
+    tick();
+    const interval = setInterval(tick, 1000);
+    return () => clearInterval(interval);
+  }, [planningDeadline]);
   const meRef = useRef<PlayerInstance | null>(null);
 
   const { roomId, playerId } = use(params);
@@ -241,6 +254,10 @@ export default function Page({
           );
         }
 
+        if (baseMessage.MessageType === "PlanningDeadline") {
+          setPlanningDeadline((data.Message as PlanningDeadlineEvent).DeadlineUnixMilli);
+        }
+
         if (baseMessage.MessageType === "Update") {
           handleServerUpdate(data.Message);
         }
@@ -350,13 +367,15 @@ export default function Page({
               PrevX: me.CurrentX,
               PrevY: me.CurrentY,
               Id: me.Id,
+              // The steps after the first one extend the move of the turn.
+              Extend: hasMoved,
             }),
           }
         );
 
         if (!response.ok) {
           const errorText = await response.text();
-          throw new Error(errorText || `Failed to submit move (${response.status})`);
+          throw new Error(describeProblem(errorText) || `Failed to submit move (${response.status})`);
         }
 
         engineRef.current.Update([
@@ -392,7 +411,7 @@ export default function Page({
         setIsSubmitting(false);
       }
     },
-    [hasAttacked, hasSkipped, me, remainingMovement, renderAroundPlayer, roomId, showDirection]
+    [hasAttacked, hasMoved, hasSkipped, me, remainingMovement, renderAroundPlayer, roomId, showDirection]
   );
 
   const handleSkip = useCallback(async () => {
@@ -412,7 +431,7 @@ export default function Page({
 
       if (!response.ok) {
         const errorText = await response.text();
-        throw new Error(errorText || `Failed to skip turn (${response.status})`);
+        throw new Error(describeProblem(errorText) || `Failed to skip turn (${response.status})`);
       }
 
       setHasMoved(false);
@@ -435,6 +454,7 @@ export default function Page({
           <div className="text-sm text-muted-foreground">
             <div>Room: {roomId}</div>
             <div>Player: {currentPlayer?.Name ?? playerId}</div>
+            {secondsLeft !== null ? <div>Turn proceeds in {secondsLeft}s</div> : null}
           </div>
         </CardHeader>
         <CardContent className="space-y-6">
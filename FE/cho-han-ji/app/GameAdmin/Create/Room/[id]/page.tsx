@@ -7,6 +7,7 @@ import { Button } from "@/components/ui/button";
 import { useRouter } from "next/navigation";
 import { Message, TypedMessage } from "@/model/SSEMessage";
 import { Teams } from "@/model/Tile";
+import { PlayerConnectedEvent, Team, TeamRostersEvent } from "@/model/generated/Events";
 import TeamTileClass from "@/components/ui/TeamTileClass";
 import TeamTextClass from "@/components/ui/TeamTextClass";
 
@@ -24,6 +25,7 @@ export default function AdminWaitingRoom({ params }: { params: Promise<{ id: str
   const [players, setPlayers] = useState<Player[]>([]);
   const [isStarting, setIsStarting] = useState(false);
   const [isCancelling, setIsCancelling] = useState(false);
+  const [teams, setTeams] = useState<Team[]>([]);
 
   useEffect(() => {
     const es = new EventSource(
@@ -38,24 +40,34 @@ export default function AdminWaitingRoom({ params }: { params: Promise<{ id: str
     es.onmessage = (event) => {
       console.log("SSE raw:", event.data);
       try {
-        const data = JSON.parse(event.data) as { MessageType: string; Message?: string };
+        const data = JSON.parse(event.data) as {
+          MessageType: string;
+          Message?: PlayerConnectedEvent | TeamRostersEvent;
+        };
 
         const baseMessage = new Message(data.MessageType);
 
+        if (baseMessage.MessageType === "TeamRosters" && data.Message && typeof data.Message === "object") {
+          const rosters = (data.Message as TeamRostersEvent).Teams ?? [];
+          setTeams(rosters.map((roster) => roster.Team));
+          setPlayers(
+            rosters.flatMap((roster) =>
+              (roster.Players ?? []).map((p) => ({ id: p.Id, name: p.Name, team: roster.Team.Number as Teams }))
+            )
+          );
+          return;
+        }
+
         if (baseMessage.MessageType === "PlayerConnected") {
           if (!data.Message) return;
 
-          // 2nd parse: Message is a JSON string
-          const playerPartial = JSON.parse(data.Message) as { id: string; name: string; team?: number };
+          const playerPartial = data.Message as PlayerConnectedEvent;
 
-          const teamValue =
-            playerPartial.team === Teams.TEAM1 || playerPartial.team === Teams.TEAM2
-              ? playerPartial.team
-              : Teams.Neutral;
+          const teamValue = playerPartial.Team in Teams ? (playerPartial.Team as Teams) : Teams.Neutral;
 
           const player: Player = {
-            id: playerPartial.id,
-            name: playerPartial.name,
+            id: playerPartial.Id,
+            name: playerPartial.Name,
             team: teamValue,
           };
 
@@ -143,24 +155,35 @@ export default function AdminWaitingRoom({ params }: { params: Promise<{ id: str
 
   const teamCounts = players.reduce(
     (acc, player) => {
-      if (player.team === Teams.TEAM1) {
-        acc.team1 += 1;
-      } else if (player.team === Teams.TEAM2) {
-        acc.team2 += 1;
+      if (player.team !== Teams.Neutral) {
+        acc.set(player.team, (acc.get(player.team) ?? 0) + 1);
       }
       return acc;
     },
-    { team1: 0, team2: 0 }
+    new Map<Teams, number>(
+      (teams.length > 0 ? teams.map((t) => t.Number as Teams) : [Teams.TEAM1, Teams.TEAM2]).map((t) => [t, 0])
+    )
   );
 
-  const formatTeamLabel = (team: Teams) => {
-    switch (team) {
-      case Teams.TEAM1:
-        return "Team 1";
-      case Teams.TEAM2:
-        return "Team 2";
-      default:
-        return "No team";
+  const formatTeamLabel = (team: Teams) =>
+    teams.find((t) => t.Number === team)?.Name ?? (team === Teams.Neutral ? "No team" : `Team ${team}`);
+
+  const handleLobbyCommand = async (command: "move" | "kick", body: Record<string, unknown>) => {
+    try {
+      const res = await fetch(
+        `${process.env.NEXT_PUBLIC_API_BASE_URL}api/lobby/${command}?roomId=${roomId}`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify(body),
+        }
+      );
+
+      if (!res.ok) {
+        throw new Error(`${command} failed: ${res.status}`);
+      }
+    } catch (err) {
+      console.error(err);
     }
   };
 
@@ -184,15 +207,14 @@ export default function AdminWaitingRoom({ params }: { params: Promise<{ id: str
               <span className="text-sm font-medium">{players.length}</span>
             </div>
 
-            <div className="flex items-center justify-between text-xs text-muted-foreground">
-              <span className={TeamTextClass(Teams.TEAM1)}>Team 1</span>
-              <span className="text-sm font-medium text-foreground">{teamCounts.team1}</span>
-            </div>
-
-            <div className="flex items-center justify-between text-xs text-muted-foreground">
-              <span className={TeamTextClass(Teams.TEAM2)}>Team 2</span>
-              <span className="text-sm font-medium text-foreground">{teamCounts.team2}</span>
-            </div>
+            {[...teamCounts.entries()]
+              .sort(([a], [b]) => a - b)
+              .map(([team, count]) => (
+                <div key={team} className="flex items-center justify-between text-xs text-muted-foreground">
+                  <span className={TeamTextClass(team)}>{formatTeamLabel(team)}</span>
+                  <span className="text-sm font-medium text-foreground">{count}</span>
+                </div>
+              ))}
           </div>
 
           {/* ✅ Buttons */}
@@ -237,7 +259,32 @@ export default function AdminWaitingRoom({ params }: { params: Promise<{ id: str
                         {formatTeamLabel(p.team)}
                       </span>
                     </div>
-                    <span className="font-mono text-xs text-muted-foreground">{p.id}</span>
+                    <div className="flex items-center gap-2">
+                      <span className="font-mono text-xs text-muted-foreground">{p.id}</span>
+                      {teams.length > 0 ? (
+                        <select
+                          aria-label="Team"
+                          className="rounded-md border bg-background px-2 py-1 text-xs"
+                          value={p.team}
+                          onChange={(e) =>
+                            handleLobbyCommand("move", { PlayerId: p.id, TeamNumber: Number(e.target.value) })
+                          }
+                        >
+                          {teams.map((t) => (
+                            <option key={t.Number} value={t.Number}>
+                              {t.Name}
+                            </option>
+                          ))}
+                        </select>
+                      ) : null}
+                      <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={() => handleLobbyCommand("kick", { PlayerId: p.id })}
+                      >
+                        Kick
+                      </Button>
+                    </div>
                   </li>
                 ))}
               </ul>
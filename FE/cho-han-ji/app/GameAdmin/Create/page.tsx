@@ -7,8 +7,9 @@ import { Label } from "@/components/ui/label";
 import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
 import { useRouter } from "next/navigation"
+import { describeProblem } from "@/model/Problem"
 
-type Payload = { MapWidth: number; MapHeight: number; Items: string };
+type Payload = { MapWidth: number; MapHeight: number; Items: string; FogOfWar: boolean };
 
 export default function CreateRoomPage() {
   const [submitting, setSubmitting] = useState(false);
@@ -25,8 +26,9 @@ export default function CreateRoomPage() {
       const mapWidth = Number(form.get("MapWidth"));
       const mapHeight = Number(form.get("MapHeight"));
       const items = String(form.get("items") ?? "");
+      const fogOfWar = form.get("FogOfWar") === "on";
 
-      const payload: Payload = { MapWidth: mapWidth, MapHeight: mapHeight, Items: items };
+      const payload: Payload = { MapWidth: mapWidth, MapHeight: mapHeight, Items: items, FogOfWar: fogOfWar };
 
       const res = await fetch(`${process.env.NEXT_PUBLIC_API_BASE_URL}api/room`, {
         method: "POST",
@@ -35,7 +37,7 @@ export default function CreateRoomPage() {
 
       if (!res.ok) {
         const text = await res.text();
-        throw new Error(text || `Request failed (${res.status})`);
+        throw new Error(describeProblem(text) || `Request failed (${res.status})`);
       }
 
       const data: { MapId: string } = await res.json();
@@ -63,7 +65,8 @@ export default function CreateRoomPage() {
                 id="MapWidth"
                 name="MapWidth"
                 type="number"
-                min={1}
+                min={4}
+                max={100}
                 step={1}
                 required
                 placeholder="e.g. 100"
@@ -76,7 +79,8 @@ export default function CreateRoomPage() {
                 id="MapHeight"
                 name="MapHeight"
                 type="number"
-                min={1}
+                min={4}
+                max={100}
                 step={1}
                 required
                 placeholder="e.g. 80"
@@ -94,7 +98,12 @@ export default function CreateRoomPage() {
               />
             </div>
 
-            {error ? <p className="text-sm text-destructive">{error}</p> : null}
+            <div className="flex items-center gap-2">
+              <input id="FogOfWar" name="FogOfWar" type="checkbox" className="h-4 w-4" />
+              <Label htmlFor="FogOfWar">Fog of war</Label>
+            </div>
+
+            {error ? <p className="whitespace-pre-line text-sm text-destructive">{error}</p> : null}
 
             <Button type="submit" className="w-full" disabled={submitting}>
               {submitting ? "Submitting..." : "Submit"}
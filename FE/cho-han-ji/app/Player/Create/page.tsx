@@ -1,7 +1,8 @@
 "use client"
 
-import React, { useState } from "react"
+import React, { useEffect, useState } from "react"
 import { useRouter } from "next/navigation"
+import { describeProblem } from "@/model/Problem"
 
 import { Button } from "@/components/ui/button"
 import { Label } from "@/components/ui/label"
@@ -15,31 +16,44 @@ import {
   SelectValue,
 } from "@/components/ui/select"
 
-type CharacterClass = "fighter" | "ranger" | "thief"
-type TeamNumber = 1 | 2
+const teamNumbers = [1, 2, 3, 4, 5, 6] as const
+
+type TeamNumber = (typeof teamNumbers)[number]
 
 type Payload = {
   RoomId: string
   UserName: string
-  Class: CharacterClass
+  Class: string
   TeamNumber: TeamNumber
 }
 
-function isCharacterClass(v: string): v is CharacterClass {
-  return v === "fighter" || v === "ranger" || v === "thief"
+type ClassDefinition = {
+  Name: string
+  Description: string
 }
 
-function isTeamNumberString(v: string): v is "1" | "2" {
-  return v === "1" || v === "2"
+function parseTeamNumber(v: string): TeamNumber | null {
+  return teamNumbers.find((n) => String(n) === v) ?? null
 }
 
 export default function CreateCharacterPage() {
   const [submitting, setSubmitting] = useState(false)
   const [error, setError] = useState<string | null>(null)
-  const [charClass, setCharClass] = useState<CharacterClass | "">("")
+  const [charClass, setCharClass] = useState("")
+  const [classes, setClasses] = useState<ClassDefinition[]>([])
   const [teamNumber, setTeamNumber] = useState<TeamNumber>(1)
   const router = useRouter()
 
+  useEffect(() => {
+    fetch(`${process.env.NEXT_PUBLIC_API_BASE_URL}api/classes`)
+      .then((res) => {
+        if (!res.ok) throw new Error(`Could not load the classes (${res.status})`)
+        return res.json() as Promise<{ Classes: ClassDefinition[] }>
+      })
+      .then((data) => setClasses(data.Classes ?? []))
+      .catch((err) => setError(err instanceof Error ? err.message : "Could not load the classes"))
+  }, [])
+
   async function onSubmit(e: React.FormEvent<HTMLFormElement>) {
     e.preventDefault()
     setError(null)
@@ -69,9 +83,11 @@ export default function CreateCharacterPage() {
         }
       )
 
+      if (res.status === 409) throw new Error("The game has already started.")
+      if (res.status === 403) throw new Error("The game has already started, it can only be watched.")
       if (!res.ok) {
         const text = await res.text()
-        throw new Error(text || `Request failed (${res.status})`)
+        throw new Error(describeProblem(text) || `Request failed (${res.status})`)
       }
 
       const data: { CharacterId: string } = await res.json()
@@ -106,22 +122,17 @@ export default function CreateCharacterPage() {
             <div className="grid gap-2">
               <Label htmlFor="Class">Class</Label>
 
-              <Select
-                value={charClass}
-                onValueChange={(value) => {
-                  // Deduction: shadcn Select always gives string, so we must narrow it
-                  if (isCharacterClass(value)) setCharClass(value)
-                  else setCharClass("") // safety fallback
-                }}
-              >
+              <Select value={charClass} onValueChange={setCharClass}>
                 <SelectTrigger id="Class">
                   <SelectValue placeholder="Select a class" />
                 </SelectTrigger>
 
                 <SelectContent>
-                  <SelectItem value="fighter">Fighter</SelectItem>
-                  <SelectItem value="ranger">Ranger</SelectItem>
-                  <SelectItem value="thief">Thief</SelectItem>
+                  {classes.map((c) => (
+                    <SelectItem key={c.Name} value={c.Name.toLowerCase()}>
+                      {c.Name}
+                    </SelectItem>
+                  ))}
                 </SelectContent>
               </Select>
             </div>
@@ -131,9 +142,10 @@ export default function CreateCharacterPage() {
                 // Deduction/work: Select expects string; state is number union => stringify it
                 value={String(teamNumber)}
                 onValueChange={(value) => {
-                  // Deduction/work: convert "1"/"2" -> 1|2 safely
-                  if (isTeamNumberString(value)) {
-                    setTeamNumber(value === "1" ? 1 : 2)
+                  // Deduction/work: convert "1".."6" -> TeamNumber safely
+                  const team = parseTeamNumber(value)
+                  if (team) {
+                    setTeamNumber(team)
                   }
                 }}
               >
@@ -142,8 +154,11 @@ export default function CreateCharacterPage() {
                 </SelectTrigger>
 
                 <SelectContent>
-                  <SelectItem value="1">1</SelectItem>
-                  <SelectItem value="2">2</SelectItem>
+                  {teamNumbers.map((n) => (
+                    <SelectItem key={n} value={String(n)}>
+                      {n}
+                    </SelectItem>
+                  ))}
                 </SelectContent>
               </Select>
 
@@ -7,6 +7,7 @@ import { useOddEvenGame } from "@/controller/Games/OddEvenGame";
 import Player, { PlayerClass } from "@/model/Player";
 import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
 import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
 import { Teams } from "@/model/Tile";
 import DirectionalControls, { Direction } from "@/components/DirectionalControls";
 import { useRouter } from "next/navigation";
@@ -113,6 +114,7 @@ export default function CharacterPage({
   const router = useRouter();
 
   const [mode, setMode] = useState<Mode>("move");
+  const [name, setName] = useState("");
 
   const [pos, setPos] = useState<{ r: number; c: number }>({ r: 0, c: 0 });
   const [enemyPos, setEnemyPos] = useState<{ r: number; c: number }>({
@@ -323,12 +325,18 @@ export default function CharacterPage({
           navigatedToGame.current = true;
           router.push(`/Game/player/${targetRoom}/${characterId}`);
         }
+
+        if (data.MessageType === "PlayerKicked" || data.MessageType === "PlayerLeft") {
+          es.close();
+          router.push("/");
+        }
       } catch (err) {
         console.log("Failed to handle SSE message", err);
       }
     };
     es.onerror = (err) => {
       console.log(err)
+      if (navigatedToGame.current) return;
       router.back();
     }
 
@@ -338,6 +346,25 @@ export default function CharacterPage({
     };
   }, [characterId, roomId, router]);
 
+  const handleLobbyCommand = async (command: "leave" | "rename", body: Record<string, unknown>) => {
+    try {
+      const res = await fetch(
+        `${process.env.NEXT_PUBLIC_API_BASE_URL}api/lobby/${command}?roomId=${roomId}`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify({ PlayerId: characterId, ...body }),
+        }
+      );
+
+      if (!res.ok) {
+        throw new Error(`${command} failed: ${res.status}`);
+      }
+    } catch (err) {
+      console.error(err);
+    }
+  };
+
   // NEW: directional buttons should be disabled in attack mode unless enemy occupies that block
   const disableDir = useCallback(
     (dir: Direction) =>
@@ -529,6 +556,26 @@ export default function CharacterPage({
             >
               Skip Turn
             </Button>
+
+            <div className="flex gap-2">
+              <Input
+                placeholder="New name"
+                maxLength={32}
+                value={name}
+                onChange={(e) => setName(e.target.value)}
+              />
+              <Button
+                variant="outline"
+                disabled={!name.trim()}
+                onClick={() => handleLobbyCommand("rename", { Name: name.trim() })}
+              >
+                Rename
+              </Button>
+            </div>
+
+            <Button variant="destructive" onClick={() => handleLobbyCommand("leave", {})}>
+              Leave Room
+            </Button>
           </div>
         </div>
       </CardContent>
@@ -6,6 +6,14 @@ export default function TeamTextClass(team: Teams) {
       return "text-blue-700 dark:text-blue-300";
     case Teams.TEAM2:
       return "text-red-700 dark:text-red-300";
+    case Teams.TEAM3:
+      return "text-green-700 dark:text-green-300";
+    case Teams.TEAM4:
+      return "text-amber-700 dark:text-amber-300";
+    case Teams.TEAM5:
+      return "text-purple-700 dark:text-purple-300";
+    case Teams.TEAM6:
+      return "text-cyan-700 dark:text-cyan-300";
     default:
       return "text-foreground";
   }
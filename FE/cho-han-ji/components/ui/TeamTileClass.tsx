@@ -6,6 +6,14 @@ export default function TeamTileClass(team: Teams) {
       return "bg-blue-50/70 dark:bg-blue-950/25 border-blue-200/60 dark:border-blue-900/40";
     case Teams.TEAM2:
       return "bg-red-50/70 dark:bg-red-950/25 border-red-200/60 dark:border-red-900/40";
+    case Teams.TEAM3:
+      return "bg-green-50/70 dark:bg-green-950/25 border-green-200/60 dark:border-green-900/40";
+    case Teams.TEAM4:
+      return "bg-amber-50/70 dark:bg-amber-950/25 border-amber-200/60 dark:border-amber-900/40";
+    case Teams.TEAM5:
+      return "bg-purple-50/70 dark:bg-purple-950/25 border-purple-200/60 dark:border-purple-900/40";
+    case Teams.TEAM6:
+      return "bg-cyan-50/70 dark:bg-cyan-950/25 border-cyan-200/60 dark:border-cyan-900/40";
     default:
       return "bg-background border-border";
   }
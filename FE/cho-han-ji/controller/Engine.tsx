@@ -28,17 +28,26 @@ export default class Engine {
       this.tiles[t.Y][t.X].Team = t.Team;
     }
 
+    // Players and items out of sight (fog of war) are sent with X and Y set to -1.
     for (const p of players) {
-      this.tiles[p.Y][p.X].Players[p.Id] = p;
       this.players[p.Id] = p;
+      if (this.isOnMap(p.X, p.Y)) {
+        this.tiles[p.Y][p.X].Players[p.Id] = p;
+      }
     }
 
     for (const i of items) {
-      this.tiles[i.Y][i.X].Items[i.Id] = i;
       this.items[i.Id] = i;
+      if (this.isOnMap(i.X, i.Y)) {
+        this.tiles[i.Y][i.X].Items[i.Id] = i;
+      }
     }
   }
 
+  private isOnMap(x: number, y: number): boolean {
+    return x >= 0 && y >= 0 && y < this.tiles.length && x < this.tiles[0].length;
+  }
+
   public Update(changes: Change[]) {
     for (const change of changes) {
       const prevTile =
@@ -133,17 +142,13 @@ export default class Engine {
     return grid;
   }
 
-  public GetChestItemsByTeam(): Record<Teams, Record<string, number>> {
-    const chestItems: Record<Teams, Record<string, number>> = {
-      [Teams.Neutral]: {},
-      [Teams.TEAM1]: {},
-      [Teams.TEAM2]: {},
-    };
+  public GetChestItemsByTeam(): Partial<Record<Teams, Record<string, number>>> {
+    const chestItems: Partial<Record<Teams, Record<string, number>>> = {};
 
     for (const row of this.tiles) {
       for (const tile of row) {
         if (tile.Flag !== Flag.TREASURE_CHEST) continue;
-        const bucket = chestItems[tile.Team] ?? chestItems[Teams.Neutral];
+        const bucket = (chestItems[tile.Team] ??= {});
 
         for (const item of Object.values(tile.Items)) {
           bucket[item.Name] = (bucket[item.Name] ?? 0) + 1;
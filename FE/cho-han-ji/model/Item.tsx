@@ -3,12 +3,18 @@ export default class Item {
   public Y: number;
   public Id: string;
   public Name: string;
+  public Kind: string;
+  public Points: number;
+  public Effect?: string;
 
-  constructor(x: number, y: number, id: string, name: string) {
+  constructor(x: number, y: number, id: string, name: string, kind = "Treasure", points = 1, effect?: string) {
     this.X = x;
     this.Y = y;
     this.Id = id;
     this.Name = name
+    this.Kind = kind
+    this.Points = points
+    this.Effect = effect
   }
 
   public static fromJSON(data: any): Item {
@@ -16,7 +22,10 @@ export default class Item {
       Number(data.X),
       Number(data.Y),
       String(data.Id),
-      String(data.Name)
+      String(data.Name),
+      data.Kind ? String(data.Kind) : undefined,
+      data.Points === undefined ? undefined : Number(data.Points),
+      data.Effect ? String(data.Effect) : undefined
     )
   }
 }
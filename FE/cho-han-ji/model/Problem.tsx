@@ -0,0 +1,23 @@
+// Problem is the body of every error response of the server.
+export type Problem = {
+  type: string;
+  title: string;
+  status: number;
+  detail?: string;
+  errors?: { field: string; rule: string; detail: string }[];
+  requestId?: string;
+};
+
+// describeProblem lists the rules the request breaks, one per line, or the
+// detail of the problem. Text that is no problem says it all.
+export function describeProblem(text: string): string {
+  try {
+    const problem = JSON.parse(text) as Problem;
+    if (problem.errors?.length) return problem.errors.map((e) => e.detail).join("\n");
+    if (problem.detail) return problem.detail;
+    if (problem.title) return problem.title;
+  } catch {
+    // Not a problem, the text says it all.
+  }
+  return text;
+}
@@ -4,7 +4,8 @@ export type PlayerChangePayload = {
   PrevX: number;
   PrevY: number;
   Id: string;
-  ItemId?: string | null;
+  Inventory: string[];
+  InterruptedBy?: string;
 };
 
 export type ItemChangePayload = {
@@ -51,7 +52,8 @@ const parsePlayerChange = (id: string, change: unknown): PlayerChangePayload | n
     PrevX,
     PrevY,
     Id,
-    ItemId: raw.ItemId ?? null,
+    Inventory: Array.isArray(raw.Inventory) ? raw.Inventory.filter((id): id is string => typeof id === "string") : [],
+    ...(typeof raw.InterruptedBy === "string" && raw.InterruptedBy !== "" ? { InterruptedBy: raw.InterruptedBy } : {}),
   };
 };
 
@@ -11,7 +11,11 @@ export enum Flag {
 export enum Teams {
   Neutral = 0,
   TEAM1 = 1,
-  TEAM2 = 2
+  TEAM2 = 2,
+  TEAM3 = 3,
+  TEAM4 = 4,
+  TEAM5 = 5,
+  TEAM6 = 6,
 }
 
 export default class Tile {
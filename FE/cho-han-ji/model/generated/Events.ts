@@ -0,0 +1,226 @@
+// Code generated by go generate ./domain/Event; DO NOT EDIT.
+
+export const SchemaVersion = 4;
+
+export type LobbyConnectionEvent = {
+  RoomId: string;
+};
+
+export type ConnectionEvent = {
+  MapHeight: number;
+  MapWidth: number;
+  Tiles: MapTile[] | null;
+  Players: Player[] | null;
+  Items: Item[] | null;
+  Readiness?: Readiness | null;
+  Teams: Team[] | null;
+};
+
+export type MapTile = {
+  X: number;
+  Y: number;
+  Flag: number;
+  Player?: Player[] | null;
+  Items?: Item[] | null;
+  Team: number;
+};
+
+export type Player = {
+  X: number;
+  Y: number;
+  Id: string;
+  Name: string;
+  Class: string;
+  Inventory: Item[] | null;
+  Team: number;
+  Status: PlayerStatus;
+};
+
+export type Item = {
+  X: number;
+  Y: number;
+  Id: string;
+  Name: string;
+  Kind: string;
+  Points: number;
+  Weight: number;
+  Effect?: string;
+  Magnitude?: number;
+};
+
+export type PlayerStatus = {
+  BonusHP: number;
+  SpeedBonus: number;
+  RevealTurns: number;
+  RespawnTurns: number;
+  Invulnerable: boolean;
+};
+
+export type Readiness = {
+  Ready: string[] | null;
+  Teams: ReadinessTeamStruct[] | null;
+};
+
+export type ReadinessTeamStruct = {
+  Team: number;
+  Ready: number;
+  Total: number;
+};
+
+export type Team = {
+  Number: number;
+  Name: string;
+  Color: string;
+};
+
+export type PingEvent = Record<string, never>;
+
+export type PlayerConnectedEvent = {
+  Id: string;
+  Name: string;
+  Team: number;
+};
+
+export type GameStartEvent = {
+  RoomId: string;
+  MapHeight: number;
+  MapWidth: number;
+};
+
+export type PlayerIsReadyEvent = {
+  PlayerId: string;
+};
+
+export type PhaseEvent = {
+  Phase: string;
+};
+
+export type FightEvent = {
+  Id: string;
+  Type: string;
+  AttackerId: string;
+  AttackerResult: unknown;
+  DefenderId: string;
+  DefenderResult: unknown;
+  WinnerId?: string;
+};
+
+export type FightResultEvent = {
+  Id: string;
+  Type: string;
+  AttackerId: string;
+  AttackerResult: unknown;
+  DefenderId: string;
+  DefenderResult: unknown;
+  WinnerId?: string;
+};
+
+export type UpdateEvent = {
+  PlayerChanges: Record<string, UpdateMessagePlayerChange> | null;
+  ItemChanges: Record<string, UpdateMessageItemChange> | null;
+  Scores?: Record<string, number> | null;
+};
+
+export type UpdateMessagePlayerChange = {
+  X: number;
+  Y: number;
+  PrevX: number;
+  PrevY: number;
+  Id: string;
+  Inventory: string[] | null;
+  InterruptedBy?: string;
+  Effects?: string[] | null;
+};
+
+export type UpdateMessageItemChange = {
+  X: number;
+  Y: number;
+  PrevX: number;
+  PrevY: number;
+  ItemId: string;
+};
+
+export type CommandRejectedEvent = {
+  Reason: string;
+};
+
+export type PlanningDeadlineEvent = {
+  DeadlineUnixMilli: number;
+  Seconds: number;
+};
+
+export type ActionWithdrawnEvent = {
+  PlayerId: string;
+};
+
+export type ReadinessChangedEvent = {
+  Ready: string[] | null;
+  Teams: ReadinessTeamStruct[] | null;
+};
+
+export type ChestRaidedEvent = {
+  RaiderId: string;
+  Team: number;
+  Items: string[] | null;
+};
+
+export type TeamRostersEvent = {
+  Teams: RoomRoster[] | null;
+};
+
+export type RoomRoster = {
+  Team: Team;
+  Players: RoomRosterPlayer[] | null;
+};
+
+export type RoomRosterPlayer = {
+  Id: string;
+  Name: string;
+  Class: string;
+};
+
+export type PlayerMovedEvent = {
+  PlayerId: string;
+  Team: number;
+};
+
+export type PlayerKickedEvent = {
+  PlayerId: string;
+};
+
+export type PlayerLeftEvent = {
+  PlayerId: string;
+};
+
+export type PlayerRenamedEvent = {
+  PlayerId: string;
+  Name: string;
+};
+
+export type Envelope<T extends string, M> = {
+  Version: number;
+  MessageType: T;
+  Message: M;
+};
+
+export type ServerMessage =
+  | Envelope<"Connection", LobbyConnectionEvent>
+  | Envelope<"Connection", ConnectionEvent>
+  | Envelope<"ping", PingEvent>
+  | Envelope<"PlayerConnected", PlayerConnectedEvent>
+  | Envelope<"GameStart", GameStartEvent>
+  | Envelope<"PlayerIsReady", PlayerIsReadyEvent>
+  | Envelope<"Phase", PhaseEvent>
+  | Envelope<"Fight", FightEvent>
+  | Envelope<"FightResult", FightResultEvent>
+  | Envelope<"Update", UpdateEvent>
+  | Envelope<"CommandRejected", CommandRejectedEvent>
+  | Envelope<"PlanningDeadline", PlanningDeadlineEvent>
+  | Envelope<"ActionWithdrawn", ActionWithdrawnEvent>
+  | Envelope<"ReadinessChanged", ReadinessChangedEvent>
+  | Envelope<"ChestRaided", ChestRaidedEvent>
+  | Envelope<"TeamRosters", TeamRostersEvent>
+  | Envelope<"PlayerMoved", PlayerMovedEvent>
+  | Envelope<"PlayerKicked", PlayerKickedEvent>
+  | Envelope<"PlayerLeft", PlayerLeftEvent>
+  | Envelope<"PlayerRenamed", PlayerRenamedEvent>;
@@ -4,7 +4,8 @@ import { Teams } from "./Tile";
 export enum PlayerClass {
   Fighter = "fighter",
   Archer = "ranger",
-  Thief = "thief"
+  Thief = "thief",
+  Rogue = "rogue"
 }
 
 class Class {
@@ -27,7 +28,7 @@ class Class {
       return new Fighter()
     } else if (c === PlayerClass.Archer) {
       return new Archer()
-    } else if (c === PlayerClass.Thief) {
+    } else if (c === PlayerClass.Thief || c === PlayerClass.Rogue) {
       return new Thief()
     } else {
       throw new Error("Class Not defined")
@@ -67,7 +68,7 @@ export default class Player {
   public Class: PlayerClass;
   private _classInfo: Class | undefined;
   public Team: Teams
-  public _bag?: Item;
+  public Inventory: Item[] = [];
 
   constructor(x: number, y: number, id: string, name: string, playerClass: PlayerClass, team: Teams) {
     this.X = x;